@@ -0,0 +1,22 @@
+//go:build !race
+
+package uid_test
+
+import (
+	"testing"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNoAllocs is left out of race builds, where sync.Pool drops shards at random and reseeding allocates.
+func TestNoAllocs(t *testing.T) {
+	// the default and seeded sources keep the ID buffers on the stack
+	buf := make([]uid.UUID, 1000)
+	for _, g := range []*uid.Generator{uid.NewGenerator(), uid.NewGenerator(uid.WithSeed([32]byte{}))} {
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _ = g.NewV4() }))
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _ = g.NewV7() }))
+		assert.Zero(t, testing.AllocsPerRun(10, func() { g.FillV4(buf) }))
+		assert.Zero(t, testing.AllocsPerRun(10, func() { g.FillV7(buf) }))
+	}
+}
@@ -0,0 +1,54 @@
+package uid
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+/*
+WithMonotonicClock derives v7 timestamps from Go's monotonic clock instead of reading the wall clock every time. The
+wall clock is read once as an anchor and timestamps are the anchor plus monotonic time elapsed since, so wall clock
+steps (NTP slews, manual changes) cannot move IDs backwards. When reanchor is positive the wall clock is consulted again
+every reanchor to stay close to real time, jumping forward to it if it is ahead but never moving backwards. Applies to
+the clock set by WithClock, whatever the option order.
+*/
+func WithMonotonicClock(reanchor time.Duration) Option {
+	return func(g *Generator) { g.anchor = &anchored{every: reanchor} }
+}
+
+// anchored is a wall clock anchor advanced by monotonic time.
+type anchored struct {
+	wall  func() time.Time
+	mono  func() time.Duration // monotonic time elapsed since an arbitrary fixed point
+	every time.Duration
+	state atomic.Pointer[anchor]
+}
+
+type anchor struct {
+	wall time.Time     // wall time at anchoring
+	mono time.Duration // monotonic time at anchoring
+}
+
+// starts a with wall as its clock and returns its now.
+func (a *anchored) start(wall func() time.Time) func() time.Time {
+	a.wall = wall
+	if a.mono == nil {
+		start := time.Now()
+		a.mono = func() time.Duration { return time.Since(start) }
+	}
+	a.state.Store(&anchor{wall: wall(), mono: a.mono()})
+	return a.now
+}
+
+func (a *anchored) now() time.Time {
+	m := a.mono()
+	cur := a.state.Load()
+	t := cur.wall.Add(m - cur.mono)
+	if a.every > 0 && m-cur.mono >= a.every {
+		if w := a.wall(); w.After(t) {
+			t = w // catch up, never back
+		}
+		a.state.CompareAndSwap(cur, &anchor{wall: t, mono: m}) // losing the race means someone else re-anchored
+	}
+	return t
+}
@@ -0,0 +1,53 @@
+package uid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonotonicClockIgnoresWallSteps(t *testing.T) {
+	wall := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mono := time.Duration(0)
+	g := uid.NewGenerator(uid.WithMonotonicClock(0), uid.WithClock(func() time.Time { return wall }))
+	uid.SetMonotonic(g, func() time.Duration { return mono })
+	first := g.NewV7()
+	assert.Exactly(t, wall.UnixMilli(), first.Time().UnixMilli())
+	// wall steps back an hour while a ms passes
+	wall, mono = wall.Add(-time.Hour), mono+time.Millisecond
+	second := g.NewV7()
+	assert.Exactly(t, -1, uid.Compare(first, second))
+	assert.Exactly(t, first.Time().UnixMilli()+1, second.Time().UnixMilli())
+	// and forward, still ignored without re-anchoring
+	wall, mono = wall.Add(2*time.Hour), mono+time.Millisecond
+	assert.Exactly(t, first.Time().UnixMilli()+2, g.NewV7().Time().UnixMilli())
+}
+
+func TestMonotonicClockReanchor(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wall, mono := start, time.Duration(0)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return wall }), uid.WithMonotonicClock(time.Second))
+	uid.SetMonotonic(g, func() time.Duration { return mono })
+	// wall runs fast, re-anchoring catches up after the interval
+	wall, mono = wall.Add(time.Minute), mono+500*time.Millisecond
+	assert.Exactly(t, start.Add(500*time.Millisecond).UnixMilli(), g.NewV7().Time().UnixMilli())
+	mono += 500 * time.Millisecond
+	assert.Exactly(t, wall.UnixMilli(), g.NewV7().Time().UnixMilli())
+	// wall steps back, re-anchoring never goes backwards
+	last := wall
+	wall, mono = wall.Add(-time.Hour), mono+2*time.Second
+	assert.Exactly(t, last.Add(2*time.Second).UnixMilli(), g.NewV7().Time().UnixMilli())
+	mono += time.Millisecond
+	assert.Exactly(t, last.Add(2*time.Second+time.Millisecond).UnixMilli(), g.NewV7().Time().UnixMilli())
+}
+
+func TestMonotonicClockReal(t *testing.T) {
+	g := uid.NewGenerator(uid.WithMonotonicClock(time.Millisecond))
+	before := time.Now()
+	id := g.NewV7Strict()
+	assert.InDelta(t, before.UnixMilli(), id.Time().UnixMilli(), 5)
+	time.Sleep(2 * time.Millisecond)
+	assert.Exactly(t, -1, uid.Compare(id, g.NewV7()))
+}
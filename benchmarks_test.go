@@ -2,7 +2,10 @@
 package uid_test
 
 import (
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/byron-janrain/uid"
 	gofrsuuid "github.com/gofrs/uuid"
@@ -10,11 +13,20 @@ import (
 )
 
 func BenchmarkV4(b *testing.B) {
+	b.ReportAllocs()
 	for range b.N {
 		_ = uid.NewV4()
 	}
 }
 
+func BenchmarkV4Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = uid.NewV4()
+		}
+	})
+}
+
 func BenchmarkGoogleV4(b *testing.B) {
 	for range b.N {
 		_, _ = googleuuid.NewRandom() // ignoring error is best-case for performance comparison but don't
@@ -28,17 +40,44 @@ func BenchmarkGofrsV4(b *testing.B) {
 }
 
 func BenchmarkV7(b *testing.B) {
+	b.ReportAllocs()
 	for range b.N {
 		_ = uid.NewV7()
 	}
 }
 
+func BenchmarkV7Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = uid.NewV7()
+		}
+	})
+}
+
 func BenchmarkV7Strict(b *testing.B) {
 	for range b.N {
 		_ = uid.NewV7Strict()
 	}
 }
 
+func BenchmarkV7StrictContention(b *testing.B) {
+	for _, goroutines := range []int{1, 2, 4, 8, 16, 32, 64} {
+		b.Run(strconv.Itoa(goroutines), func(b *testing.B) {
+			wg := sync.WaitGroup{}
+			for range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range b.N / goroutines {
+						_ = uid.NewV7Strict()
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
 func BenchmarkGoogleV7(b *testing.B) {
 	for range b.N {
 		_, _ = googleuuid.NewV7() // ignoring error is unrealistic but best-case for performance comparison
@@ -51,6 +90,53 @@ func BenchmarkGofrsV7(b *testing.B) {
 	}
 }
 
+const batch = 1024
+
+func BenchmarkLoopV4(b *testing.B) {
+	ids := make([]uid.UUID, batch)
+	for range b.N {
+		for i := range ids {
+			ids[i] = uid.NewV4()
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/id")
+}
+
+func BenchmarkFillV4(b *testing.B) {
+	ids := make([]uid.UUID, batch)
+	for range b.N {
+		uid.FillV4(ids)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/id")
+}
+
+func BenchmarkLoopV7Strict(b *testing.B) {
+	ids := make([]uid.UUID, batch)
+	for range b.N {
+		for i := range ids {
+			ids[i] = uid.NewV7Strict()
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/id")
+}
+
+func BenchmarkFillV7(b *testing.B) {
+	ids := make([]uid.UUID, batch)
+	for range b.N {
+		uid.FillV7(ids)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/id")
+}
+
+func BenchmarkSeqV4(b *testing.B) {
+	for range b.N {
+		for id := range uid.SeqV4(batch) {
+			_ = id
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/id")
+}
+
 func BenchmarkParseNil(b *testing.B) {
 	for range b.N {
 		_, _ = uid.Parse(uid.NilCanonical)
@@ -98,3 +184,91 @@ func BenchmarkParseGofrs7(b *testing.B) {
 		_, _ = gofrsuuid.FromString(ref7)
 	}
 }
+
+func BenchmarkPoolV4(b *testing.B) {
+	p := uid.NewPool(4096)
+	defer p.Close()
+	b.ResetTimer()
+	for range b.N {
+		_ = p.NewV4()
+	}
+}
+
+func BenchmarkPoolV4Parallel(b *testing.B) {
+	p := uid.NewPool(4096)
+	defer p.Close()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = p.NewV4()
+		}
+	})
+}
+
+func BenchmarkV4Health(b *testing.B) {
+	g := uid.NewGenerator(uid.WithHealthTests(func(uid.HealthTest) bool { return true }))
+	for range b.N {
+		_ = g.NewV4()
+	}
+}
+
+func BenchmarkFillV4Health(b *testing.B) {
+	g := uid.NewGenerator(uid.WithHealthTests(func(uid.HealthTest) bool { return true }))
+	ids := make([]uid.UUID, batch)
+	b.ResetTimer()
+	for range b.N {
+		g.FillV4(ids)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/id")
+}
+
+func BenchmarkEntropy(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		g    *uid.Generator
+	}{
+		{"ChaCha8Pool", uid.NewGenerator()},
+		{"CryptoRand", uid.NewGenerator(uid.WithCryptoEntropy())},
+		{"Seeded", uid.NewGenerator(uid.WithSeed([32]byte{}))},
+	} {
+		name, g := bc.name, bc.g
+		b.Run(name+"V4", func(b *testing.B) {
+			for range b.N {
+				_ = g.NewV4()
+			}
+		})
+		b.Run(name+"V4Parallel", func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = g.NewV4()
+				}
+			})
+		})
+		b.Run(name+"V7", func(b *testing.B) {
+			for range b.N {
+				_ = g.NewV7()
+			}
+		})
+	}
+}
+
+func BenchmarkV4CloneDetection(b *testing.B) {
+	g := uid.NewGenerator(uid.WithCloneDetection(time.Second, "", nil))
+	for range b.N {
+		_ = g.NewV4()
+	}
+}
+
+func BenchmarkV4Stats(b *testing.B) {
+	g := uid.NewGenerator(uid.WithStats())
+	for range b.N {
+		_ = g.NewV4()
+	}
+}
+
+func BenchmarkV7Stats(b *testing.B) {
+	g := uid.NewGenerator(uid.WithStats())
+	for range b.N {
+		_ = g.NewV7()
+	}
+}
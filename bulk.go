@@ -0,0 +1,95 @@
+package uid
+
+import (
+	"iter"
+)
+
+const chunk = 256 // IDs worth of entropy drawn per read by bulk generation
+
+// FillV4 fills dst with new v4 UUIDs.
+func FillV4(dst []UUID) { std.FillV4(dst) }
+
+// FillV4 fills dst with new v4 UUIDs, drawing entropy in large chunks instead of once per ID.
+func (g *Generator) FillV4(dst []UUID) {
+	if g.stats != nil {
+		g.stats.v4.Add(uint64(len(dst)))
+	}
+	var buf [chunk * 16]byte
+	for len(dst) > 0 {
+		n := min(len(dst), chunk)
+		g.read(buf[:n*16])
+		for i := range n {
+			b := (*[16]byte)(buf[i*16:])
+			// version, variant
+			b[6], b[8] = (b[6]&0x0f)|0x40, (b[8]&0x3f)|0x80 //nolint:mnd // lob
+			dst[i] = UUID{*b}
+		}
+		dst = dst[n:]
+	}
+}
+
+// FillV7 fills dst with new v7 UUIDs that are strictly increasing (by Compare) and sort after every strict ID issued
+// before them.
+func FillV7(dst []UUID) { std.FillV7(dst) }
+
+// FillV7 fills dst with strictly increasing v7 UUIDs. Slots are claimed a millisecond's worth at a time, so the clock
+// is read once per millisecond the batch spans, and entropy is drawn in large chunks. Shares NewV7Strict's monotonicity
+// state, so the batch sorts strictly after every strict ID g issued before it and before every one after it.
+func (g *Generator) FillV7(dst []UUID) {
+	if g.precision > 0 {
+		ms := g.tick().ms()
+		for i := range dst {
+			dst[i] = g.makeCoarse(ms)
+		}
+		return
+	}
+	if g.stats != nil {
+		g.stats.v7.Add(uint64(len(dst)))
+	}
+	for todo := dst; len(todo) > 0; {
+		first, k := g.claim(len(todo))
+		for i := range k {
+			put7(&todo[i].b, first+stamp(i))
+		}
+		todo = todo[k:]
+	}
+	var buf [chunk * 8]byte
+	for len(dst) > 0 {
+		n := min(len(dst), chunk)
+		g.read(buf[:n*8])
+		for i := range n {
+			copy(dst[i].b[8:], buf[i*8:(i+1)*8])
+			dst[i].b[8] = (dst[i].b[8] & 0x3f) | 0x80 //nolint:mnd // variant
+			g.putNode(&dst[i].b)
+		}
+		dst = dst[n:]
+	}
+}
+
+// SeqV4 returns an iterator over n new v4 UUIDs generated in bulk.
+func SeqV4(n int) iter.Seq[UUID] { return std.SeqV4(n) }
+
+// SeqV4 returns an iterator over n new v4 UUIDs generated in bulk by g.
+func (g *Generator) SeqV4(n int) iter.Seq[UUID] { return g.seq(n, g.FillV4) }
+
+// SeqV7 returns an iterator over n new strictly increasing v7 UUIDs generated in bulk.
+func SeqV7(n int) iter.Seq[UUID] { return std.SeqV7(n) }
+
+// SeqV7 returns an iterator over n new strictly increasing v7 UUIDs generated in bulk by g. Slots are claimed one
+// chunk at a time as the iterator is consumed so IDs stay close to the time they are yielded.
+func (g *Generator) SeqV7(n int) iter.Seq[UUID] { return g.seq(n, g.FillV7) }
+
+func (g *Generator) seq(n int, fill func([]UUID)) iter.Seq[UUID] {
+	return func(yield func(UUID) bool) {
+		var buf [chunk]UUID
+		for left := n; left > 0; left -= chunk {
+			ids := buf[:min(left, chunk)]
+			fill(ids)
+			for _, id := range ids {
+				if !yield(id) {
+					return
+				}
+			}
+		}
+	}
+}
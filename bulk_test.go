@@ -0,0 +1,91 @@
+package uid_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillV4(t *testing.T) {
+	defer uid.ReseedPRNG()()
+	ids := make([]uid.UUID, 1000) // spans several chunks
+	uid.FillV4(ids)
+	seen := map[uid.UUID]bool{}
+	for _, id := range ids {
+		assert.Exactly(t, uid.Version4, id.Version())
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+		seen[id] = true
+	}
+	assert.Len(t, seen, len(ids))
+	// same stream as NewV4
+	defer uid.ReseedPRNG()()
+	assert.Exactly(t, "d9877ece-6d36-4aac-9a6f-419ec627c76b", ids[0].String())
+	uid.FillV4(nil) // no-op
+}
+
+func TestFillV7(t *testing.T) {
+	freezeNow := time.Now()
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return freezeNow }))
+	before := g.NewV7Strict()
+	ids := make([]uid.UUID, 10_000) // spans more than 2 frozen ms
+	g.FillV7(ids)
+	after := g.NewV7StrictAt(freezeNow)
+	for _, id := range ids {
+		assert.Exactly(t, uid.Version7, id.Version())
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+	}
+	// strictly increasing within the batch and relative to strict IDs around it
+	all := slices.Concat([]uid.UUID{before}, ids, []uid.UUID{after})
+	for i := 1; i < len(all); i++ {
+		assert.Exactly(t, -1, uid.Compare(all[i-1], all[i]))
+	}
+	assert.Exactly(t, freezeNow.UnixMilli(), ids[0].Time().UnixMilli())
+	uid.FillV7(nil) // no-op
+	uid.FillV7(ids[:1])
+	assert.Exactly(t, uid.Version7, ids[0].Version())
+}
+
+func TestFillV7CatchesUp(t *testing.T) {
+	// clock jumps a second every read, a batch crossing a ms boundary must jump with it
+	clock := time.Now()
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { clock = clock.Add(time.Second); return clock }))
+	ids := make([]uid.UUID, 5000)
+	g.FillV7(ids)
+	assert.True(t, slices.IsSortedFunc(ids, uid.Compare))
+	assert.Greater(t, ids[len(ids)-1].Time().Sub(ids[0].Time()), time.Second)
+}
+
+func TestSeqV4(t *testing.T) {
+	seen := map[uid.UUID]bool{}
+	for id := range uid.SeqV4(600) {
+		assert.Exactly(t, uid.Version4, id.Version())
+		seen[id] = true
+	}
+	assert.Len(t, seen, 600)
+	// early break
+	n := 0
+	for range uid.SeqV4(600) {
+		n++
+		if n == 10 {
+			break
+		}
+	}
+	assert.Exactly(t, 10, n)
+}
+
+func TestSeqV7(t *testing.T) {
+	seq := uid.NewGenerator().SeqV7(600)
+	ids := slices.Collect(seq)
+	assert.Len(t, ids, 600)
+	// reusable, every pass claims new slots
+	ids = append(ids, slices.Collect(seq)...)
+	for i := 1; i < len(ids); i++ {
+		assert.Exactly(t, uid.Version7, ids[i].Version())
+		assert.Exactly(t, -1, uid.Compare(ids[i-1], ids[i]))
+	}
+	assert.Empty(t, slices.Collect(uid.SeqV7(-1)))
+	assert.Len(t, slices.Collect(uid.SeqV7(1)), 1)
+}
@@ -0,0 +1,87 @@
+package uid
+
+import (
+	"time"
+)
+
+// ClockPolicy selects how a Generator's v7 paths treat a wall clock that moves backwards (NTP corrections, VM
+// migrations). Clocks outside the range v7 can represent (before the Unix epoch or after year 10889) are always clamped
+// to the nearest end, generation never panics.
+type ClockPolicy byte
+
+const (
+	// ClockFollow follows the clock backwards, NewV7 IDs minted after a regression sort before earlier ones. Strict,
+	// counter and monotonic random paths stay ordered regardless. This is the default.
+	ClockFollow = ClockPolicy(iota)
+
+	// ClockHold holds the highest timestamp seen while the clock is behind it and keeps counting slots forward from
+	// there, so NewV7 IDs never go backwards.
+	ClockHold
+)
+
+// ClockEventKind identifies what a ClockEvent reports.
+type ClockEventKind byte
+
+const (
+	// ClockRegressed reports a clock reading behind the highest one seen.
+	ClockRegressed = ClockEventKind(iota + 1)
+
+	// ClockBeforeEpoch reports a clock reading before the Unix epoch, clamped to the epoch.
+	ClockBeforeEpoch
+
+	// ClockOverflow reports a clock reading beyond 2^48-1 ms after the Unix epoch (year 10889), clamped to it.
+	ClockOverflow
+)
+
+// ClockEvent describes an anomalous clock reading seen by a Generator.
+type ClockEvent struct {
+	Kind  ClockEventKind
+	Clock time.Time // the offending reading
+	Last  time.Time // the highest (slotted) reading seen before it, only set for ClockRegressed
+}
+
+// WithClockPolicy sets how the Generator treats clock regressions. When report is non-nil it is called synchronously,
+// from the generating goroutine, for every anomalous clock reading, so keep it fast. Defaults to ClockFollow without
+// reporting.
+func WithClockPolicy(policy ClockPolicy, report func(ClockEvent)) Option {
+	return func(g *Generator) { g.policy, g.report = policy, report }
+}
+
+// reads g's clock applying its ClockPolicy. held reports the clock is behind the highest reading seen and ClockHold
+// replaced it with that reading.
+func (g *Generator) now() (stamp, bool) { return g.nowFrom(g.clock()) }
+
+// applies g's ClockPolicy to the clock reading t, see now.
+func (g *Generator) nowFrom(t time.Time) (stamp, bool) {
+	s := stampOf(t)
+	if g.policy == ClockFollow && g.report == nil && g.stats == nil { // fast path
+		return s, false
+	}
+	if g.report != nil {
+		switch {
+		case t.Unix() < 0:
+			g.report(ClockEvent{Kind: ClockBeforeEpoch, Clock: t})
+		case t.Unix() > maxMs/1000 || t.UnixMilli() > maxMs:
+			g.report(ClockEvent{Kind: ClockOverflow, Clock: t})
+		}
+	}
+	for {
+		seen := stamp(g.seen.Load())
+		if s >= seen {
+			if s == seen || g.seen.CompareAndSwap(uint64(seen), uint64(s)) {
+				return s, false
+			}
+			continue
+		}
+		if g.stats != nil {
+			g.stats.regressions.Add(1)
+		}
+		if g.report != nil {
+			g.report(ClockEvent{Kind: ClockRegressed, Clock: t, Last: seen.time()})
+		}
+		if g.policy == ClockHold {
+			return seen, true
+		}
+		return s, false
+	}
+}
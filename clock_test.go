@@ -0,0 +1,115 @@
+package uid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+var year10889 = time.UnixMilli(1<<48 - 1) //nolint:gochecknoglobals // test data
+
+func TestClockFollowBackwards(t *testing.T) {
+	clock := time.Now()
+	defer uid.SetNowFunc(func() time.Time { return clock })()
+	before, beforeStrict := uid.NewV7(), uid.NewV7Strict()
+	clock = clock.Add(-time.Minute)
+	// default policy follows the clock for NewV7 but strict never goes backwards
+	assert.Exactly(t, 1, uid.Compare(before, uid.NewV7()))
+	assert.Exactly(t, -1, uid.Compare(beforeStrict, uid.NewV7Strict()))
+}
+
+func TestClockRangeClamped(t *testing.T) {
+	defer uid.SetNowFunc(func() time.Time { return time.Unix(0, 0) })()
+	assert.Exactly(t, int64(0), uid.NewV7().Time().UnixNano())
+	uid.SetNowFunc(func() time.Time { return time.Unix(-1, 0) })
+	assert.Exactly(t, int64(0), uid.NewV7().Time().UnixNano())
+	// last representable ms
+	uid.SetNowFunc(func() time.Time { return year10889 })
+	assert.Exactly(t, year10889.UnixMilli(), uid.NewV7().Time().UnixMilli())
+	// beyond it
+	uid.SetNowFunc(func() time.Time { return year10889.Add(time.Hour) })
+	assert.Exactly(t, year10889.UnixMilli(), uid.NewV7().Time().UnixMilli())
+	// strict saturates instead of wrapping to epoch (own generator, saturation is permanent)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return year10889 }))
+	assert.Exactly(t, year10889.UnixMilli(), g.NewV7Strict().Time().UnixMilli())
+	g = uid.NewGenerator(uid.WithClock(func() time.Time { return year10889.Add(time.Hour) }))
+	assert.Exactly(t, "ffffffff-ffff-7fff", g.NewV7Strict().String()[:18])
+	assert.Exactly(t, "ffffffff-ffff-7fff", g.NewV7Strict().String()[:18])
+}
+
+func TestClockHold(t *testing.T) {
+	clock := time.Now()
+	events := []uid.ClockEvent{}
+	g := uid.NewGenerator(
+		uid.WithClock(func() time.Time { return clock }),
+		uid.WithClockPolicy(uid.ClockHold, func(e uid.ClockEvent) { events = append(events, e) }),
+	)
+	first := g.NewV7()
+	high := clock
+	clock = clock.Add(-time.Second)
+	prev := first
+	for range 10 {
+		id := g.NewV7()
+		assert.Exactly(t, -1, uid.Compare(prev, id)) // held and counting forward
+		assert.Exactly(t, high.UnixMilli(), id.Time().UnixMilli())
+		prev = id
+	}
+	assert.Len(t, events, 10)
+	assert.Exactly(t, uid.ClockRegressed, events[0].Kind)
+	assert.Exactly(t, clock, events[0].Clock)
+	assert.Exactly(t, first.Time(), events[0].Last)
+	// clock recovers
+	clock = high.Add(time.Second)
+	assert.Exactly(t, clock.UnixMilli(), g.NewV7().Time().UnixMilli())
+	assert.Len(t, events, 10)
+}
+
+func TestClockHoldRecovers(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return clock }), uid.WithClockPolicy(uid.ClockHold, nil))
+	prev := g.NewV7()
+	high := clock
+	clock = clock.Add(-time.Second)
+	for range 50_000 { // held long enough to count well past the held ms
+		id := g.NewV7()
+		assert.Exactly(t, -1, uid.Compare(prev, id))
+		prev = id
+	}
+	assert.Greater(t, prev.Time().Sub(high), 10*time.Millisecond)
+	// clock recovers to just past the held timestamp, still behind the slots counted while held
+	for _, step := range []time.Duration{time.Millisecond, time.Millisecond, 20 * time.Millisecond} {
+		clock = high.Add(step)
+		high = clock
+		id := g.NewV7()
+		assert.Exactly(t, -1, uid.Compare(prev, id))
+		prev = id
+	}
+	assert.Exactly(t, clock.UnixMilli(), prev.Time().UnixMilli()) // caught up
+}
+
+func TestClockReportRange(t *testing.T) {
+	clock := time.Unix(-10, 0)
+	events := []uid.ClockEvent{}
+	g := uid.NewGenerator(
+		uid.WithClock(func() time.Time { return clock }),
+		uid.WithClockPolicy(uid.ClockFollow, func(e uid.ClockEvent) { events = append(events, e) }),
+	)
+	assert.Exactly(t, int64(0), g.NewV7().Time().UnixNano())
+	clock = time.Unix(0, 0) // epoch itself is fine
+	_ = g.NewV7()
+	clock = year10889 // so is the last ms
+	_ = g.NewV7()
+	clock = year10889.Add(time.Millisecond)
+	assert.Exactly(t, year10889.UnixMilli(), g.NewV7().Time().UnixMilli())
+	clock = time.Unix(1<<62, 0)
+	assert.Exactly(t, year10889.UnixMilli(), g.NewV7Strict().Time().UnixMilli())
+	clock = year10889.Add(-time.Hour) // regression, followed
+	assert.Exactly(t, clock.UnixMilli(), g.NewV7().Time().UnixMilli())
+	kinds := []uid.ClockEventKind{}
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Exactly(t, []uid.ClockEventKind{uid.ClockBeforeEpoch, uid.ClockOverflow, uid.ClockOverflow, uid.ClockRegressed}, kinds)
+}
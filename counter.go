@@ -0,0 +1,81 @@
+package uid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+)
+
+const (
+	minCounterBits, maxCounterBits = 12, 42 // RFC9562 §6.2 recommends 12-42 counter bits
+	randBBits                      = 62
+	randBMask                      = 1<<randBBits - 1
+)
+
+// WithCounterBits sets the width of the V7Counter counter. The top 12 bits live in rand_a, the remainder in the top of
+// rand_b (right below the node when combined with WithNode). Panics unless 12 <= bits <= 42. Defaults to 12.
+func WithCounterBits(bits int) Option {
+	if bits < minCounterBits || bits > maxCounterBits {
+		panic("uid: counter bits must be between 12 and 42")
+	}
+	return func(g *Generator) { g.ctrBits = bits }
+}
+
+// counter is the state of RFC9562 method 1 (fixed-length dedicated counter) generation.
+type counter struct {
+	ctrBits int    // counter width, rand_a plus ctrBits-12 bits of rand_b
+	ctrMs   int64  // unix_ts_ms the counter belongs to
+	ctr     uint64 // last issued counter value
+}
+
+/*
+makeCounter builds a method 1 v7. The counter is seeded randomly each new ms with its top bit clear (the RFC's rollover
+guard) and incremented for every ID within the ms. On rollover the timestamp is advanced by one ms (ahead of the clock
+if need be) and the counter reseeded, so IDs are strictly increasing (by CompareAll) without waiting on the clock. A
+clock that moves backwards is treated as still being in the last ms.
+*/
+//nolint:mnd // locality of behavior
+func (g *Generator) makeCounter() UUID {
+	var b [16]byte
+	g.read(b[:])
+	seed, rb := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])&randBMask
+	ms := g.tick().ms()
+	g.mu.Lock()
+	width := g.ctrBits
+	limit := uint64(1)<<width - 1
+	switch {
+	case ms > g.ctrMs:
+		g.ctrMs, g.ctr = ms, seed&(limit>>1)
+	case g.ctr == limit: // rollover
+		g.ctrMs, g.ctr = min(g.ctrMs+1, maxMs), seed&(limit>>1)
+	default:
+		g.ctr++
+	}
+	ms, ctr := g.ctrMs, g.ctr
+	g.mu.Unlock()
+	// counter rides on top of whatever rand_b is left (below the node, if any)
+	extra, shift := width-minCounterBits, randBBits-g.nodeBits-(width-minCounterBits)
+	rb = rb>>(randBBits-shift) | (ctr&(1<<extra-1))<<shift
+	put7(&b, stamp(ms)<<12|stamp(ctr>>extra))
+	binary.BigEndian.PutUint64(b[8:], rb|0x8000_0000_0000_0000) // variant
+	g.putNode(&b)
+	if g.stats != nil {
+		g.stats.v7.Add(1)
+	}
+	return UUID{b}
+}
+
+// TimeFor returns the embedded timestamp of a v7 UUID generated in mode. Only method 3 modes (V7Method3, V7Strict)
+// encode sub-millisecond precision in rand_a, other modes return millisecond precision. For non-V7 zero(time.Time) is
+// returned.
+func (u UUID) TimeFor(mode V7Mode) time.Time {
+	t := u.Time()
+	if mode == V7Method3 || mode == V7Strict || t.IsZero() {
+		return t
+	}
+	return t.Truncate(time.Millisecond)
+}
+
+// CompareAll orders a and b by all 128 bits. Use it instead of Compare for IDs from modes whose monotonic counters
+// extend past rand_a (V7Counter with more than 12 bits, V7MonotonicRandom).
+func CompareAll(a, b UUID) int { return bytes.Compare(a.b[:], b.b[:]) }
@@ -0,0 +1,85 @@
+package uid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV7Counter(t *testing.T) {
+	freezeNow := time.Now()
+	g := uid.NewGenerator(uid.WithV7Mode(uid.V7Counter), uid.WithClock(func() time.Time { return freezeNow }))
+	prev := g.NewV7()
+	assert.Exactly(t, uid.Version7, prev.Version())
+	assert.Exactly(t, uid.Variant9562, prev.Variant())
+	assert.Exactly(t, freezeNow.Truncate(time.Millisecond).UnixNano(), prev.TimeFor(uid.V7Counter).UnixNano())
+	// seeded with the rollover guard bit clear
+	assert.Less(t, prev.Bytes()[6]&0x0f, byte(0x08))
+	// a frozen clock exhausts the 12 bit counter within a few thousand IDs, rollover must keep strict ordering
+	for range 20_000 {
+		id := g.NewV7()
+		assert.Exactly(t, -1, uid.Compare(prev, id))
+		prev = id
+	}
+	assert.Greater(t, prev.Time().UnixMilli(), freezeNow.UnixMilli()) // rolled over into later ms
+	id, ok := uid.Parse(prev.String())
+	assert.True(t, ok)
+	assert.Exactly(t, prev, id)
+}
+
+func TestV7CounterWide(t *testing.T) {
+	freezeNow := time.Now()
+	g := uid.NewGenerator(
+		uid.WithV7Mode(uid.V7Counter),
+		uid.WithCounterBits(42),
+		uid.WithClock(func() time.Time { return freezeNow }),
+	)
+	prev := g.NewV7()
+	for range 20_000 {
+		id := g.NewV7()
+		assert.Exactly(t, -1, uid.CompareAll(prev, id))
+		assert.LessOrEqual(t, uid.Compare(prev, id), 0) // counter continues in rand_b
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+		prev = id
+	}
+	assert.Exactly(t, freezeNow.UnixMilli(), prev.Time().UnixMilli()) // 2^41 guard room, no rollover
+}
+
+func TestV7CounterClockBackwards(t *testing.T) {
+	clock := time.Now()
+	g := uid.NewGenerator(uid.WithV7Mode(uid.V7Counter), uid.WithClock(func() time.Time { return clock }))
+	first := g.NewV7()
+	clock = clock.Add(-time.Hour)
+	second := g.NewV7()
+	assert.Exactly(t, -1, uid.Compare(first, second))
+	assert.Exactly(t, first.Time().UnixMilli(), second.Time().UnixMilli()) // held at the last ms
+	clock = clock.Add(2 * time.Hour)
+	third := g.NewV7()
+	assert.Exactly(t, clock.UnixMilli(), third.Time().UnixMilli())
+}
+
+func TestWithCounterBitsBounds(t *testing.T) {
+	assert.Panics(t, func() { uid.WithCounterBits(11) })
+	assert.Panics(t, func() { uid.WithCounterBits(43) })
+	assert.NotPanics(t, func() { uid.WithCounterBits(12) })
+}
+
+func TestTimeFor(t *testing.T) {
+	at := time.Date(2024, 2, 29, 12, 0, 0, 123_456_789, time.UTC)
+	id := uid.NewV7At(at)
+	assert.Exactly(t, id.Time(), id.TimeFor(uid.V7Method3))
+	assert.Exactly(t, id.Time(), id.TimeFor(uid.V7Strict))
+	assert.Exactly(t, at.Truncate(time.Millisecond).UnixNano(), id.TimeFor(uid.V7Counter).UnixNano())
+	assert.True(t, uid.NewV4().TimeFor(uid.V7Counter).IsZero())
+}
+
+func TestCompareAll(t *testing.T) {
+	assert.Exactly(t, 0, uid.CompareAll(uid.Max(), uid.Max()))
+	assert.Exactly(t, -1, uid.CompareAll(uid.Nil(), uid.Max()))
+	a, _ := uid.Parse("0191e843-b452-7ac4-b853-8ee3953a28af")
+	b, _ := uid.Parse("0191e843-b452-7ac4-b853-8ee3953a28b0")
+	assert.Exactly(t, 0, uid.Compare(a, b))
+	assert.Exactly(t, -1, uid.CompareAll(a, b))
+}
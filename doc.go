@@ -2,7 +2,8 @@
 Package uid ...
 
 UUID V7 uses Method 3 (Replace Leftmost Random Bits with Increased Clock Precision) to implement single-node
-monotonicity.
+monotonicity. Generators can opt into Method 1 (Fixed-Length Dedicated Counter Bits) with V7Counter or
+Method 2 (Monotonic Random) with V7MonotonicRandom.
 */
 package uid
 
@@ -39,12 +40,30 @@ const (
 	// VersionNil is the Nil UUID version.
 	VersionNil = Version(0b0000_0000)
 
+	// Version1 is the version of Gregorian time-based UUIDs.
+	Version1 = Version(0b0000_0001)
+
+	// Version2 is the version of DCE Security UUIDs.
+	Version2 = Version(0b0000_0010)
+
+	// Version3 is the version of name-based UUIDs hashed with MD5.
+	Version3 = Version(0b0000_0011)
+
 	// Version4 is the version of random UUIDs.
 	Version4 = Version(0b0000_0100)
 
+	// Version5 is the version of name-based UUIDs hashed with SHA-1.
+	Version5 = Version(0b0000_0101)
+
+	// Version6 is the version of reordered Gregorian time-based UUIDs.
+	Version6 = Version(0b0000_0110)
+
 	// Version7 is the version of time-sortable UUIDs.
 	Version7 = Version(0b0000_0111)
 
+	// Version8 is the version of UUIDs with vendor-specific (custom) layouts.
+	Version8 = Version(0b0000_1000)
+
 	// VersionMax is the Max UUID version.
 	VersionMax = Version(0b0000_1111)
 
@@ -55,7 +74,7 @@ const (
 type Variant byte
 
 const (
-	// Variant9562 is the value of the variant bits of v4 or v7.
+	// Variant9562 is the value of the variant bits of v1 through v8.
 	Variant9562 = Variant(0b0000_0010)
 
 	// VariantNil is the value of the variant bits of a Nil UUID.
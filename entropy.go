@@ -0,0 +1,109 @@
+package uid
+
+import (
+	"io"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+// pool is a sharded source of ChaCha8 output. ChaCha8 is not safe for concurrent use so each caller borrows a whole
+// generator. sync.Pool keeps per-P caches, so under parallel load every P reuses its own shard without locking and
+// without racing on shared state. Every shard is independently seeded from crypto/rand.
+type pool struct {
+	shards   sync.Pool
+	onHealth func(HealthTest) bool // health test failure hook, nil when health tests are off
+	epoch    atomic.Uint64         // bumped by reseed, shards from an older epoch re-key before use
+}
+
+// shard is a ChaCha8 and the health test state of its output.
+type shard struct {
+	c     *rand.ChaCha8
+	h     health
+	epoch uint64 // pool epoch c was keyed in
+}
+
+func newPool() *pool {
+	p := new(pool)
+	p.shards.New = func() any { return &shard{c: newChaCha8(), epoch: p.epoch.Load()} }
+	p.shards.Put(&shard{c: newChaCha8()}) // seed eagerly so crypto/rand failures surface at init
+	return p
+}
+
+// Read implements io.Reader. Never returns errors.
+func (p *pool) Read(b []byte) (int, error) {
+	s := p.shards.Get().(*shard) //nolint:forcetypeassert,errcheck // only ever holds *shard
+	if e := p.epoch.Load(); s.epoch != e {
+		s.c, s.h, s.epoch = newChaCha8(), health{}, e
+	}
+	_, _ = s.c.Read(b) //nolint:errcheck // does not return errors
+	if p.onHealth != nil {
+		s.c = verify(s.c, &s.h, p.onHealth, b)
+	}
+	p.shards.Put(s)
+	return len(b), nil
+}
+
+// verify runs b (just read from c) through h. On failure onHealth decides whether c is replaced by a freshly seeded
+// ChaCha8 that regenerates b. Returns the ChaCha8 to keep using.
+func verify(c *rand.ChaCha8, h *health, onHealth func(HealthTest) bool, b []byte) *rand.ChaCha8 {
+	test, ok := h.check(b)
+	if ok || !onHealth(test) {
+		return c
+	}
+	c, *h = newChaCha8(), health{}
+	_, _ = c.Read(b) //nolint:errcheck // does not return errors
+	h.check(b)
+	return c
+}
+
+// readFrom fills b from r. The built-in sources are called directly so b stays on the caller's stack, any other reader
+// fills a heap copy instead.
+func readFrom(r io.Reader, b []byte) error {
+	switch r := r.(type) {
+	case *pool:
+		_, _ = r.Read(b) //nolint:errcheck // does not return errors
+	case *lockedChaCha8:
+		_, _ = r.Read(b) //nolint:errcheck // does not return errors
+	default:
+		tmp := make([]byte, len(b))
+		_, err := io.ReadFull(r, tmp)
+		copy(b, tmp)
+		return err //nolint:wrapcheck // passthru
+	}
+	return nil
+}
+
+// use real crypto/rand to seed a new ChaCha8 generator.
+func newChaCha8() *rand.ChaCha8 {
+	var seed [32]byte
+	if _, err := cryptoRead(seed[:]); err != nil {
+		panic("unable to initialize seed from crypto/rand") // untestable
+	}
+	return rand.NewChaCha8(seed)
+}
+
+// cryptoSource reads directly from crypto/rand.
+type cryptoSource struct{}
+
+// Read implements io.Reader.
+func (cryptoSource) Read(b []byte) (int, error) { return cryptoRead(b) }
+
+// lockedChaCha8 serializes access to a single ChaCha8 for callers that need a reproducible stream.
+type lockedChaCha8 struct {
+	mu       sync.Mutex
+	c        *rand.ChaCha8
+	h        health
+	onHealth func(HealthTest) bool // health test failure hook, nil when health tests are off
+}
+
+// Read implements io.Reader. Never returns errors.
+func (l *lockedChaCha8) Read(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.c.Read(b) //nolint:errcheck // does not return errors
+	if l.onHealth != nil {
+		l.c = verify(l.c, &l.h, l.onHealth, b)
+	}
+	return len(b), nil
+}
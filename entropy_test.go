@@ -0,0 +1,38 @@
+package uid_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelGeneration(t *testing.T) {
+	// hammer every generator from many goroutines at once, run with -race to catch shared generator state
+	const perWorker = 20_000
+	workers := 4 * runtime.GOMAXPROCS(0)
+	results := make([][]uid.UUID, workers)
+	wg := sync.WaitGroup{}
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]uid.UUID, 0, 3*perWorker)
+			for range perWorker {
+				ids = append(ids, uid.NewV4(), uid.NewV7(), uid.NewV7Strict())
+			}
+			results[w] = ids
+		}()
+	}
+	wg.Wait()
+	seen := make(map[uid.UUID]bool, workers*3*perWorker)
+	for _, ids := range results {
+		for _, id := range ids {
+			assert.False(t, seen[id])
+			seen[id] = true
+		}
+	}
+	assert.Len(t, seen, workers*3*perWorker)
+}
@@ -1,34 +1,81 @@
 package uid
 
 import (
+	"math/rand/v2"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func PoisonInit() {
+	defer func(old func([]byte) (int, error)) { cryptoRead = old }(cryptoRead) // later shards must still seed
 	cryptoRead = func(_ []byte) (int, error) { return 0, assert.AnError }
 	_init()
 }
 
-// ReseedPRNG seeds the underlying ChaCha8 with zero to make it testably predictable.
+// ReseedPRNG swaps the sharded pool for a single zero seeded ChaCha8 to make it testably predictable.
 func ReseedPRNG() func() {
-	old, err := rng.MarshalBinary()
-	if err != nil {
-		panic(err)
-	}
-	rng.Seed([32]byte{})
-	return func() {
-		if err := rng.UnmarshalBinary(old); err != nil {
-			panic(err)
-		}
-	}
+	old := std.rand
+	std.rand = &lockedChaCha8{c: rand.NewChaCha8([32]byte{})}
+	return func() { std.rand = old }
 }
 
-// SetNowFunc replaces the internal time.Now for unit testing returns a deferrable that undoes this change.
+// SetNowFunc replaces the default Generator's clock for unit testing returns a deferrable that undoes this change.
 func SetNowFunc(f func() time.Time) func() {
-	now = f
+	std.clock = f
 	return func() {
-		now = time.Now
+		std.clock = time.Now
 	}
 }
+
+// SetMonotonicRandom overwrites g's V7MonotonicRandom state to reach overflow edges without minting 2^30 IDs.
+func SetMonotonicRandom(g *Generator, ms int64, randA, randB uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mrMs, g.mrA, g.mrB = ms, randA, randB
+}
+
+// SetMonotonic replaces the monotonic time source of a WithMonotonicClock Generator and re-anchors it.
+func SetMonotonic(g *Generator, mono func() time.Duration) {
+	g.anchor.mono = mono
+	g.clock = g.anchor.start(g.anchor.wall)
+}
+
+// SetMachineSources replaces the machine-id path and hostname lookup used by MachineNode.
+func SetMachineSources(path string, host func() (string, error)) func() {
+	oldPath, oldHost := machineIDPath, hostname
+	machineIDPath, hostname = path, host
+	return func() { machineIDPath, hostname = oldPath, oldHost }
+}
+
+// CheckHealth feeds reads through one health test state, returning the first failure.
+func CheckHealth(reads ...[]byte) (HealthTest, bool) {
+	var h health
+	for _, b := range reads {
+		if test, ok := h.check(b); !ok {
+			return test, false
+		}
+	}
+	return 0, true
+}
+
+// VerifyHealth runs b through the failure path of a health checked ChaCha8.
+func VerifyHealth(onFail func(HealthTest) bool, b []byte) {
+	verify(rand.NewChaCha8([32]byte{}), &health{}, onFail, b)
+}
+
+// SetCryptoRead replaces crypto/rand's Read, returns a deferrable that undoes this change.
+func SetCryptoRead(f func([]byte) (int, error)) func() {
+	old := cryptoRead
+	cryptoRead = f
+	return func() { cryptoRead = old }
+}
+
+// SetCloneMonotonic replaces the monotonic time source of a WithCloneDetection Generator and restarts detection.
+func SetCloneMonotonic(g *Generator, mono func() time.Duration) {
+	g.detector.mono = mono
+	g.detector.start(g.detector.wall)
+}
+
+// EntropyEpoch returns the reseed epoch of g's default ChaCha8 pool.
+func EntropyEpoch(g *Generator) uint64 { return g.rand.(*pool).epoch.Load() } //nolint:forcetypeassert // tests only
@@ -0,0 +1,154 @@
+package uid
+
+import (
+	"io"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Generator mints UUIDs from its own clock, entropy source and v7 monotonicity state. Use one per component, tenant or
+// shard that needs isolation (or a fake clock), otherwise use the package functions which delegate to a default
+// Generator. A Generator is safe for concurrent use and must be constructed with NewGenerator.
+type Generator struct {
+	clock      func() time.Time
+	rand       io.Reader
+	onHealth   func(HealthTest) bool // set by WithHealthTests
+	fallback   func(error)           // set by WithEntropyFallback
+	backup     io.Reader             // serves reads the entropy source failed, nil without a fallback
+	detector   *cloneDetector        // set by WithCloneDetection
+	stats      *stats                // set by WithStats
+	epoch      atomic.Uint64         // bumped by Reseed, tags IDs buffered by Pools
+	mode       V7Mode
+	policy     ClockPolicy
+	report     func(ClockEvent)
+	seen       atomic.Uint64 // highest stamp read from clock, only tracked with ClockHold, a report func or stats
+	anchor     *anchored     // set by WithMonotonicClock
+	node       uint64        // node ID stamped into the top nodeBits of v7 rand_b
+	nodeBits   int
+	sharedPath string
+	shared     *sharedState // host wide strict sequence, nil when unused or unavailable
+	sharedErr  func(error)  // reports shared state failures, may be nil
+	hw         *highWater   // persisted high-water mark, nil when unused
+	maxDrift   time.Duration
+	maxLead    time.Duration
+	precision  int64 // v7 timestamp granularity in ms, 0 for full precision
+
+	last atomic.Uint64 // last stamp issued by the strict paths, advanced by compare-and-swap
+
+	mu         sync.Mutex // guards counter and monoRandom
+	counter               // V7Counter state
+	monoRandom            // V7MonotonicRandom state
+}
+
+// V7Mode selects how a Generator's NewV7 orders IDs minted within the same millisecond.
+type V7Mode byte
+
+const (
+	// V7Method3 uses RFC9562 method 3 (sub-millisecond clock precision in rand_a). This is the default.
+	V7Method3 = V7Mode(iota)
+
+	// V7Strict makes NewV7 behave like NewV7Strict.
+	V7Strict
+
+	// V7Counter uses RFC9562 method 1 (fixed-length dedicated counter in rand_a and optionally the top of rand_b, see
+	// WithCounterBits). Ordering within a millisecond is guaranteed without waiting on the clock but rand_a no longer
+	// carries sub-millisecond time, see UUID.TimeFor.
+	V7Counter
+
+	// V7MonotonicRandom uses RFC9562 method 2 (rand_b incremented by a random amount within a millisecond). IDs are
+	// strictly increasing by CompareAll, rand_a carries no time, see UUID.TimeFor.
+	V7MonotonicRandom
+)
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// WithClock sets the Generator's wall clock. Defaults to time.Now.
+func WithClock(clock func() time.Time) Option { return func(g *Generator) { g.clock = clock } }
+
+// WithEntropy sets the Generator's source of random bits. Generation panics if r fails, unless WithEntropyFallback is
+// set. Defaults to a pool of ChaCha8 generators seeded from crypto/rand.
+func WithEntropy(r io.Reader) Option { return func(g *Generator) { g.rand = r } }
+
+// WithCryptoEntropy makes the Generator draw every random bit directly from crypto/rand instead of a user-space
+// ChaCha8, e.g. when running in FIPS 140 mode requires all randomness to come from the approved DRBG. Slower, see the
+// benchmarks.
+func WithCryptoEntropy() Option { return WithEntropy(cryptoSource{}) }
+
+/*
+WithEntropyFallback makes a failing entropy source non-fatal: report is called with the error and the read is served
+from a pool of ChaCha8 generators seeded from crypto/rand instead. Without it generation panics when the source fails.
+Falling back defeats the purpose of WithCryptoEntropy under FIPS, leave it unset there.
+*/
+func WithEntropyFallback(report func(error)) Option {
+	return func(g *Generator) { g.fallback = report }
+}
+
+// WithSeed makes the Generator draw random bits from a single ChaCha8 seeded with seed. Output is reproducible for a
+// given seed (and clock), so this is meant for tests and simulations, never for production IDs.
+func WithSeed(seed [32]byte) Option {
+	return func(g *Generator) { g.rand = &lockedChaCha8{c: rand.NewChaCha8(seed)} }
+}
+
+// WithV7Mode sets the monotonicity mode used by the Generator's NewV7. Defaults to V7Method3.
+func WithV7Mode(mode V7Mode) Option { return func(g *Generator) { g.mode = mode } }
+
+// NewGenerator constructs a Generator configured by opts.
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{clock: time.Now, counter: counter{ctrBits: minCounterBits}, maxDrift: defaultMaxDrift}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.precision > 0 && g.mode != V7Method3 {
+		panic("uid: WithPrecision requires V7Method3")
+	}
+	if g.rand == nil {
+		g.rand = newPool()
+	}
+	if g.fallback != nil {
+		g.backup = newPool()
+	}
+	for _, r := range []io.Reader{g.rand, g.backup} {
+		switch r := r.(type) {
+		case *pool:
+			r.onHealth = g.onHealth
+		case *lockedChaCha8:
+			r.onHealth = g.onHealth
+		}
+	}
+	if g.detector != nil {
+		g.detector.start(g.clock)
+	}
+	if g.anchor != nil {
+		g.clock = g.anchor.start(g.clock)
+	}
+	if g.sharedPath != "" {
+		var err error
+		if g.shared, err = openShared(g.sharedPath); err != nil {
+			g.sharedFail(err)
+		}
+	}
+	if g.hw != nil {
+		g.loadHighWater()
+	}
+	return g
+}
+
+// read fills b from g's entropy source.
+func (g *Generator) read(b []byte) {
+	if g.detector != nil && g.detector.cloned() {
+		g.Reseed()
+		if g.detector.report != nil {
+			g.detector.report()
+		}
+	}
+	if err := readFrom(g.rand, b); err != nil {
+		if g.fallback == nil {
+			panic("uid: entropy source failed")
+		}
+		g.fallback(err)
+		_ = readFrom(g.backup, b) //nolint:errcheck // pool never fails
+	}
+}
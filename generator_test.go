@@ -0,0 +1,87 @@
+package uid_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorSeedMatchesDefault(t *testing.T) {
+	defer uid.ReseedPRNG()()
+	g := uid.NewGenerator(uid.WithSeed([32]byte{}))
+	assert.Exactly(t, uid.NewV4(), g.NewV4())
+}
+
+func TestGeneratorIsolated(t *testing.T) {
+	a, b := uid.NewGenerator(uid.WithSeed([32]byte{1})), uid.NewGenerator(uid.WithSeed([32]byte{1}))
+	_ = a.NewV4() // advancing a must not advance b
+	c := uid.NewGenerator(uid.WithSeed([32]byte{1}))
+	assert.Exactly(t, c.NewV4(), b.NewV4())
+}
+
+func TestGeneratorClock(t *testing.T) {
+	freezeNow := time.Date(2000, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return freezeNow }))
+	id := g.NewV7()
+	assert.Exactly(t, uid.Version7, id.Version())
+	assert.Exactly(t, freezeNow.UnixMilli(), id.Time().UnixMilli())
+	// package functions are untouched
+	assert.NotEqual(t, freezeNow.UnixMilli(), uid.NewV7().Time().UnixMilli())
+}
+
+func TestGeneratorEntropy(t *testing.T) {
+	r := bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))
+	g := uid.NewGenerator(uid.WithEntropy(r))
+	assert.Exactly(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", g.NewV4().String())
+	assert.Panics(t, func() { _ = g.NewV4() }) // exhausted reader
+	// any never failing reader works
+	seeded := uid.NewGenerator(uid.WithEntropy(rand.NewChaCha8([32]byte{})))
+	assert.Exactly(t, uid.NewGenerator(uid.WithSeed([32]byte{})).NewV4(), seeded.NewV4())
+}
+
+func TestGeneratorCryptoEntropy(t *testing.T) {
+	var reads int
+	defer uid.SetCryptoRead(func(b []byte) (int, error) {
+		reads++
+		for i := range b {
+			b[i] = 0xff
+		}
+		return len(b), nil
+	})()
+	g := uid.NewGenerator(uid.WithCryptoEntropy())
+	assert.Exactly(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", g.NewV4().String())
+	assert.Exactly(t, uint64(0xbfff_ffff_ffff_ffff), binary.BigEndian.Uint64(g.NewV7().Bytes()[8:]))
+	assert.Exactly(t, 2, reads)
+}
+
+func TestGeneratorEntropyFallback(t *testing.T) {
+	var errs []error
+	r := bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))
+	g := uid.NewGenerator(uid.WithEntropy(r), uid.WithEntropyFallback(func(err error) { errs = append(errs, err) }))
+	assert.Exactly(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", g.NewV4().String())
+	assert.Empty(t, errs)
+	// exhausted reader falls back instead of panicking
+	id := g.NewV4()
+	assert.Exactly(t, uid.Version4, id.Version())
+	assert.NotEqual(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", id.String())
+	assert.Len(t, errs, 1)
+	ids := make([]uid.UUID, 1000)
+	g.FillV7(ids)
+	assert.Len(t, errs, 5) // one per 256 ID chunk
+}
+
+func TestGeneratorV7ModeStrict(t *testing.T) {
+	g := uid.NewGenerator(uid.WithV7Mode(uid.V7Strict))
+	ids := make([]uid.UUID, 0, 1000)
+	for range cap(ids) {
+		ids = append(ids, g.NewV7())
+	}
+	assert.True(t, slices.IsSortedFunc(ids, uid.Compare))
+	assert.Len(t, slices.CompactFunc(ids, func(a, b uid.UUID) bool { return uid.Compare(a, b) == 0 }), cap(ids))
+}
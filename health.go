@@ -0,0 +1,71 @@
+package uid
+
+import (
+	"encoding/binary"
+)
+
+// HealthTest identifies an SP 800-90B §4.4 continuous health test.
+type HealthTest byte
+
+const (
+	// HealthRepetitionCount fails when consecutive 64-bit output words repeat (§4.4.1).
+	HealthRepetitionCount = HealthTest(iota + 1)
+
+	// HealthAdaptiveProportion fails when the first byte of a 512-byte window recurs too often within it (§4.4.2).
+	HealthAdaptiveProportion
+)
+
+// cutoffs for a false positive rate of about 2^-40 assuming full entropy (H=64 per word, H=8 per byte).
+const (
+	rctCutoff  = 2
+	aptWindow  = 512
+	aptCutoff  = 20
+	bytesInU64 = 8
+)
+
+/*
+WithHealthTests runs continuous repetition count and adaptive proportion tests (SP 800-90B §4.4) over the output of
+the Generator's built-in ChaCha8 sources (the default pool, or WithSeed). Custom WithEntropy readers are left to their
+owners. On failure onFail is called synchronously with the failed test, and when it returns true the failing
+generator is reseeded from crypto/rand and the output regenerated, so failing output is never used. Off by default.
+*/
+func WithHealthTests(onFail func(HealthTest) (reseed bool)) Option {
+	return func(g *Generator) { g.onHealth = onFail }
+}
+
+// health is the running state of the continuous health tests over one ChaCha8 stream.
+type health struct {
+	last uint64 // previous 64-bit word
+	run  int    // length of the current run of repeated words
+
+	first       byte // first byte of the current adaptive proportion window
+	seen, count int  // bytes seen in the window and occurrences of first
+}
+
+// check feeds b through the health tests, returning the first failing test.
+func (h *health) check(b []byte) (HealthTest, bool) {
+	for i := 0; i+bytesInU64 <= len(b); i += bytesInU64 {
+		w := binary.LittleEndian.Uint64(b[i:])
+		if h.run > 0 && w == h.last {
+			if h.run++; h.run >= rctCutoff {
+				return HealthRepetitionCount, false
+			}
+			continue
+		}
+		h.last, h.run = w, 1
+	}
+	for _, c := range b {
+		switch {
+		case h.seen == 0:
+			h.first, h.count = c, 1
+		case c == h.first:
+			if h.count++; h.count >= aptCutoff {
+				return HealthAdaptiveProportion, false
+			}
+		}
+		if h.seen++; h.seen == aptWindow {
+			h.seen = 0
+		}
+	}
+	return 0, true
+}
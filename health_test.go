@@ -0,0 +1,75 @@
+package uid_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthPasses(t *testing.T) {
+	c := rand.NewChaCha8([32]byte{1})
+	reads := make([][]byte, 1<<14)
+	for i := range reads {
+		reads[i] = make([]byte, 16*(1+i%256)) // every read size from NewV4 to a full FillV4 chunk
+		_, _ = c.Read(reads[i])
+	}
+	_, ok := uid.CheckHealth(reads...)
+	assert.True(t, ok)
+}
+
+func TestHealthRepetitionCount(t *testing.T) {
+	word := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	test, ok := uid.CheckHealth(append(bytes.Clone(word), word...))
+	assert.False(t, ok)
+	assert.Exactly(t, uid.HealthRepetitionCount, test)
+	// across reads
+	test, ok = uid.CheckHealth(word, word)
+	assert.False(t, ok)
+	assert.Exactly(t, uid.HealthRepetitionCount, test)
+}
+
+func TestHealthAdaptiveProportion(t *testing.T) {
+	window := func(repeats int) []byte {
+		b := make([]byte, 512)
+		for i := range b {
+			b[i] = byte(i%255) + 1 // distinct words, no byte value recurs more than 3 times
+		}
+		for i := range repeats {
+			b[i*25] = 0
+		}
+		return b
+	}
+	_, ok := uid.CheckHealth(window(19), window(19))
+	assert.True(t, ok)
+	test, ok := uid.CheckHealth(window(20))
+	assert.False(t, ok)
+	assert.Exactly(t, uid.HealthAdaptiveProportion, test)
+}
+
+func TestHealthFailureHook(t *testing.T) {
+	var got []uid.HealthTest
+	stuck := make([]byte, 64)
+	uid.VerifyHealth(func(test uid.HealthTest) bool { got = append(got, test); return false }, stuck)
+	assert.Exactly(t, []uid.HealthTest{uid.HealthRepetitionCount}, got)
+	assert.Exactly(t, make([]byte, 64), stuck) // kept
+	uid.VerifyHealth(func(uid.HealthTest) bool { return true }, stuck)
+	assert.NotEqual(t, make([]byte, 64), stuck) // regenerated from a reseeded ChaCha8
+}
+
+func TestWithHealthTests(t *testing.T) {
+	fail := func(uid.HealthTest) bool { t.Fatal("healthy ChaCha8 failed"); return false }
+	for _, g := range []*uid.Generator{
+		uid.NewGenerator(uid.WithHealthTests(fail)),
+		uid.NewGenerator(uid.WithHealthTests(fail), uid.WithSeed([32]byte{2})),
+	} {
+		ids := make([]uid.UUID, 10_000)
+		g.FillV4(ids)
+		g.FillV7(ids)
+		for range 10_000 {
+			_, _ = g.NewV4(), g.NewV7()
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package uid
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HighWaterStore persists a Generator's v7 high-water mark across restarts. Load returns the zero time (and no error)
+// when nothing has been stored yet.
+type HighWaterStore interface {
+	Load() (time.Time, error)
+	Save(mark time.Time) error
+}
+
+/*
+WithHighWater makes the Generator's strict paths (see WithSharedState) never issue a timestamp at or below the mark
+loaded from store at construction, even when the clock came back behind it after a restart. Whenever an issued slot
+passes the saved mark a new mark, lease ahead of it, is saved before the ID is returned, so every ID ever issued is
+below the stored mark and saves happen about once per lease. Store failures never fail generation, they are passed to
+onError (when non-nil) and generation continues with the in-memory mark. ClockHold also holds at the loaded mark.
+*/
+func WithHighWater(store HighWaterStore, lease time.Duration, onError func(error)) Option {
+	return func(g *Generator) { g.hw = &highWater{store: store, lease: lease, onError: onError} }
+}
+
+// highWater is the persisted lease on v7 timestamps.
+type highWater struct {
+	store   HighWaterStore
+	lease   time.Duration
+	onError func(error)
+	mu      sync.Mutex    // serializes saves
+	mark    atomic.Uint64 // stamp last saved
+}
+
+// loads the stored mark and fast-forwards g's sequences past it.
+func (g *Generator) loadHighWater() {
+	mark, err := g.hw.store.Load()
+	if err != nil {
+		g.hw.fail(err)
+		return
+	}
+	if mark.IsZero() {
+		return
+	}
+	s := stampOf(mark)
+	g.hw.mark.Store(uint64(s))
+	g.last.Store(max(g.last.Load(), uint64(s)))
+	g.seen.Store(max(g.seen.Load(), uint64(s)))
+}
+
+// saves a new mark when end passed the saved one. Callers crossing the mark wait for the save.
+func (g *Generator) persist(end stamp) {
+	if g.hw == nil || uint64(end) <= g.hw.mark.Load() {
+		return
+	}
+	g.hw.mu.Lock()
+	defer g.hw.mu.Unlock()
+	if uint64(end) <= g.hw.mark.Load() { // saved while waiting
+		return
+	}
+	next := stampOf(end.time().Add(g.hw.lease))
+	if err := g.hw.store.Save(next.time()); err != nil {
+		g.hw.fail(err)
+	}
+	g.hw.mark.Store(uint64(next))
+}
+
+func (h *highWater) fail(err error) {
+	if h.onError != nil {
+		h.onError(err)
+	}
+}
+
+// FileStore returns a HighWaterStore keeping the mark as text in the file at path. Saves replace the file atomically.
+func FileStore(path string) HighWaterStore { return fileStore(path) }
+
+type fileStore string
+
+// Load implements HighWaterStore.
+func (f fileStore) Load() (time.Time, error) {
+	var mark time.Time
+	b, err := os.ReadFile(string(f))
+	if errors.Is(err, fs.ErrNotExist) {
+		return mark, nil
+	}
+	if err != nil {
+		return mark, err //nolint:wrapcheck // passthru
+	}
+	return mark, mark.UnmarshalText(b) //nolint:wrapcheck // passthru
+}
+
+// Save implements HighWaterStore.
+func (f fileStore) Save(mark time.Time) error {
+	b, err := mark.UTC().MarshalText()
+	if err != nil {
+		return err //nolint:wrapcheck // passthru
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), filepath.Base(string(f))+".*")
+	if err != nil {
+		return err //nolint:wrapcheck // passthru
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // gone after a successful rename
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // already failing
+		return err  //nolint:wrapcheck // passthru
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // already failing
+		return err  //nolint:wrapcheck // passthru
+	}
+	if err := tmp.Close(); err != nil {
+		return err //nolint:wrapcheck // passthru
+	}
+	return os.Rename(tmp.Name(), string(f)) //nolint:wrapcheck // passthru
+}
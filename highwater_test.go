@@ -0,0 +1,124 @@
+package uid_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore is a HighWaterStore that survives simulated crashes (dropped Generators).
+type memStore struct {
+	mark       time.Time
+	saves      int
+	loadErr    error
+	saveErr    error
+	beforeSave func()
+}
+
+func (m *memStore) Load() (time.Time, error) { return m.mark, m.loadErr }
+
+func (m *memStore) Save(mark time.Time) error {
+	if m.beforeSave != nil {
+		m.beforeSave()
+	}
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	m.mark = mark
+	m.saves++
+	return nil
+}
+
+func TestHighWaterCrash(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &memStore{}
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return clock }), uid.WithHighWater(store, time.Second, nil))
+	issued := []uid.UUID{}
+	for range 100 {
+		issued = append(issued, g.NewV7Strict())
+		clock = clock.Add(100 * time.Millisecond) // 10s, saved about once per lease
+	}
+	assert.InDelta(t, 10, store.saves, 1)
+	assert.True(t, store.mark.After(issued[len(issued)-1].Time()))
+	// crash, host clock comes back an hour behind
+	clock = clock.Add(-time.Hour)
+	g = uid.NewGenerator(uid.WithClock(func() time.Time { return clock }), uid.WithHighWater(store, time.Second, nil))
+	next := g.NewV7Strict()
+	for _, id := range issued {
+		assert.Exactly(t, -1, uid.Compare(id, next))
+	}
+	buf := make([]uid.UUID, 10)
+	g.FillV7(buf)
+	assert.Exactly(t, -1, uid.Compare(next, buf[0]))
+}
+
+func TestHighWaterSavedBeforeReturn(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &memStore{}
+	var last uid.UUID
+	store.beforeSave = func() { last = uid.Nil() }
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return clock }), uid.WithHighWater(store, time.Minute, nil))
+	last = g.NewV7Strict()
+	assert.False(t, last.IsNil()) // assigned after the save ran
+	assert.Exactly(t, clock.Add(time.Minute).UnixMilli(), store.mark.UnixMilli())
+}
+
+func TestHighWaterHold(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &memStore{mark: clock.Add(time.Hour)}
+	g := uid.NewGenerator(
+		uid.WithClock(func() time.Time { return clock }),
+		uid.WithClockPolicy(uid.ClockHold, nil),
+		uid.WithHighWater(store, time.Second, nil),
+	)
+	assert.True(t, g.NewV7().Time().After(store.mark.Add(-time.Second)))
+}
+
+func TestHighWaterErrors(t *testing.T) {
+	clock := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	errs := []error{}
+	store := &memStore{loadErr: assert.AnError, saveErr: assert.AnError}
+	g := uid.NewGenerator(
+		uid.WithClock(func() time.Time { return clock }),
+		uid.WithHighWater(store, time.Second, func(err error) { errs = append(errs, err) }),
+	)
+	first := g.NewV7Strict()
+	assert.Exactly(t, clock.UnixMilli(), first.Time().UnixMilli()) // keeps generating
+	assert.Exactly(t, -1, uid.Compare(first, g.NewV7Strict()))
+	assert.Exactly(t, []error{assert.AnError, assert.AnError}, errs) // load, save (in memory mark covers the 2nd)
+	// nil handler is fine
+	g = uid.NewGenerator(uid.WithClock(func() time.Time { return clock }), uid.WithHighWater(store, time.Second, nil))
+	assert.Exactly(t, clock.UnixMilli(), g.NewV7Strict().Time().UnixMilli())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := uid.FileStore(filepath.Join(dir, "uid.mark"))
+	mark, err := store.Load()
+	require.NoError(t, err)
+	assert.True(t, mark.IsZero())
+	want := time.Date(2024, 6, 1, 12, 0, 0, 123_456_789, time.UTC)
+	require.NoError(t, store.Save(want))
+	mark, err = store.Load()
+	require.NoError(t, err)
+	assert.True(t, want.Equal(mark))
+	// crash-restart through the file
+	clock := want.Add(-time.Hour)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return clock }), uid.WithHighWater(store, time.Second, nil))
+	assert.True(t, g.NewV7Strict().Time().After(want))
+	// errors
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad"), []byte("not a time"), 0o600))
+	_, err = uid.FileStore(filepath.Join(dir, "bad")).Load()
+	require.Error(t, err)
+	_, err = uid.FileStore(dir).Load() // directory
+	require.Error(t, err)
+	require.Error(t, uid.FileStore(filepath.Join(dir, "missing", "uid.mark")).Save(want))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2) // no temp files left behind
+}
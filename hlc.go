@@ -0,0 +1,39 @@
+package uid
+
+import (
+	"time"
+)
+
+const defaultMaxDrift = time.Minute
+
+// WithMaxDrift bounds how far ahead of the Generator's clock a UUID passed to Observe may be. Defaults to one minute.
+func WithMaxDrift(d time.Duration) Option { return func(g *Generator) { g.maxDrift = d } }
+
+/*
+Observe makes g a hybrid logical clock: it advances g's strict sequence to the timestamp of u (a v7 received from
+another node), so every ID g's strict paths (NewV7Strict, NewV7 in V7Strict mode, FillV7, ...) issue afterwards sorts
+after u, even when the local clock lags the remote one. It returns false, leaving g unchanged, for non-v7 UUIDs and for
+UUIDs more than the maximum drift (see WithMaxDrift) ahead of g's clock, which protects against a remote node with a
+broken clock dragging everyone into the future.
+*/
+func (g *Generator) Observe(u UUID) bool {
+	if u.Version() != Version7 {
+		return false
+	}
+	r := u.stamp()
+	if r.time().Sub(g.clock()) > g.maxDrift {
+		return false
+	}
+	for {
+		last := g.last.Load()
+		if last >= uint64(r) || g.last.CompareAndSwap(last, uint64(r)) {
+			return true
+		}
+	}
+}
+
+// Drift returns how far g's strict sequence is running ahead of its clock, from observed remote IDs or from issuing
+// faster than one ID per slot. Zero when the clock is ahead.
+func (g *Generator) Drift() time.Duration {
+	return max(stamp(g.last.Load()).time().Sub(g.clock()), 0)
+}
@@ -0,0 +1,60 @@
+package uid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve(t *testing.T) {
+	local := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return local }), uid.WithV7Mode(uid.V7Strict))
+	assert.Zero(t, g.Drift())
+	// remote node's clock runs 30s ahead
+	remote := uid.NewV7At(local.Add(30 * time.Second))
+	assert.True(t, g.Observe(remote))
+	next := g.NewV7()
+	assert.Exactly(t, -1, uid.Compare(remote, next))
+	assert.InDelta(t, 30*time.Second, g.Drift(), float64(time.Millisecond))
+	// observing the past changes nothing
+	assert.True(t, g.Observe(uid.NewV7At(local.Add(-time.Hour))))
+	assert.Exactly(t, -1, uid.Compare(next, g.NewV7Strict()))
+	// wall time catches up
+	local = local.Add(time.Minute)
+	assert.Zero(t, g.Drift())
+	assert.Exactly(t, local.UnixMilli(), g.NewV7().Time().UnixMilli())
+}
+
+func TestObserveBounds(t *testing.T) {
+	local := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return local }), uid.WithMaxDrift(time.Second))
+	assert.False(t, g.Observe(uid.NewV7At(local.Add(2*time.Second))))
+	assert.False(t, g.Observe(uid.NewV4()))
+	assert.False(t, g.Observe(uid.Max()))
+	assert.Zero(t, g.Drift())
+	assert.True(t, g.Observe(uid.NewV7At(local.Add(time.Second))))
+	assert.InDelta(t, time.Second, g.Drift(), float64(time.Millisecond))
+	// default bound
+	g = uid.NewGenerator(uid.WithClock(func() time.Time { return local }))
+	assert.True(t, g.Observe(uid.NewV7At(local.Add(time.Minute))))
+	assert.False(t, g.Observe(uid.NewV7At(local.Add(time.Minute+time.Millisecond))))
+}
+
+func TestObserveCausality(t *testing.T) {
+	// two nodes with skewed clocks exchanging messages stay causally ordered
+	clockA, clockB := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 11, 59, 50, 0, time.UTC)
+	a := uid.NewGenerator(uid.WithClock(func() time.Time { return clockA }))
+	b := uid.NewGenerator(uid.WithClock(func() time.Time { return clockB }))
+	msg := a.NewV7Strict()
+	for range 100 {
+		assert.True(t, b.Observe(msg))
+		reply := b.NewV7Strict()
+		assert.Exactly(t, -1, uid.Compare(msg, reply))
+		assert.True(t, a.Observe(reply))
+		msg = a.NewV7Strict()
+		assert.Exactly(t, -1, uid.Compare(reply, msg))
+		clockA, clockB = clockA.Add(time.Microsecond), clockB.Add(time.Microsecond)
+	}
+}
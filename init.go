@@ -2,25 +2,23 @@ package uid
 
 import (
 	crand "crypto/rand"
-	"math/rand/v2"
-	"time"
 )
 
 //nolint:gochecknoglobals // manipulatable via functions in export_test
 var (
 	cryptoRead = crand.Read
-	rng        *rand.ChaCha8
-	now        = time.Now
+	std        *Generator // default Generator behind the package functions
 )
 
 //nolint:gochecknoinits // indirect for testability
 func init() { _init() }
 
-// use real crypto/rand to initialize the ChaCha8 generator.
-func _init() {
-	var seed [32]byte
-	if _, err := cryptoRead(seed[:]); err != nil {
-		panic("unable to initialize seed from crypto/rand") // untestable
-	}
-	rng = rand.NewChaCha8(seed)
-}
+// use real crypto/rand to initialize the default Generator's sharded ChaCha8 pool.
+func _init() { std = NewGenerator() }
+
+/*
+SetDefault replaces the Generator behind the package functions, e.g. with one using WithCryptoEntropy so NewV4 and
+NewV7 draw from crypto/rand. Not safe for concurrent use with the package functions, call it from init or early in
+main.
+*/
+func SetDefault(g *Generator) { std = g }
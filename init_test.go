@@ -8,3 +8,9 @@ import (
 )
 
 func TestInit(t *testing.T) { assert.Panics(t, func() { uid.PoisonInit() }) }
+
+func TestSetDefault(t *testing.T) {
+	defer uid.SetDefault(uid.NewGenerator())
+	uid.SetDefault(uid.NewGenerator(uid.WithSeed([32]byte{})))
+	assert.Exactly(t, uid.NewGenerator(uid.WithSeed([32]byte{})).NewV4(), uid.NewV4())
+}
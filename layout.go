@@ -0,0 +1,141 @@
+package uid
+
+import (
+	"encoding/binary"
+)
+
+const payloadBits = 122 // v8 bits left after version and variant
+
+// Field is a named unsigned bit field of a Layout.
+type Field struct {
+	Name string
+	Bits int // 1 to 64
+}
+
+/*
+Layout packs typed fields (tenant IDs, coarse timestamps, shard numbers, ...) into the 122 custom bits of v8 UUIDs
+and decodes them back. Fields are packed in order from the most significant bit, skipping the version and variant bits,
+so v8 IDs of one Layout sort by their first field, then their second, and so on. Bits no field claims are filled with
+randomness. A Layout is immutable and safe for concurrent use.
+*/
+type Layout struct {
+	fields []Field
+	random int // trailing payload bits left to randomness
+}
+
+// NewLayout returns the Layout of fields. Panics unless every field is 1 to 64 bits wide and uniquely named, and all
+// fields fit in 122 bits.
+func NewLayout(fields ...Field) *Layout {
+	l := &Layout{fields: append([]Field(nil), fields...), random: payloadBits}
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if f.Bits < 1 || f.Bits > 64 || seen[f.Name] {
+			panic("uid: layout fields must be uniquely named and 1 to 64 bits wide")
+		}
+		seen[f.Name], l.random = true, l.random-f.Bits
+	}
+	if l.random < 0 {
+		panic("uid: layout fields must fit in 122 bits")
+	}
+	return l
+}
+
+// Fields returns a copy of l's fields.
+func (l *Layout) Fields() []Field { return append([]Field(nil), l.fields...) }
+
+/*
+Encode packs values into a new v8 UUID with the remaining bits random, drawn from the default Generator. See
+Generator.Encode.
+*/
+func (l *Layout) Encode(values ...uint64) (UUID, bool) { return std.Encode(l, values...) }
+
+/*
+Encode packs values into a new v8 UUID of Layout l with the remaining bits random, drawn from g's entropy source (so a
+WithSeed Generator encodes reproducibly). values are positional, one per field in the order of l.Fields(), not matched
+by name. It returns the Nil UUID and false when the number of values does not match the fields or a value overflows
+its field.
+*/
+func (g *Generator) Encode(l *Layout, values ...uint64) (UUID, bool) {
+	if len(values) != len(l.fields) {
+		return UUID{}, false
+	}
+	var p u128
+	for i, f := range l.fields {
+		if f.Bits < 64 && values[i]>>f.Bits != 0 {
+			return UUID{}, false
+		}
+		p = p.shl(f.Bits)
+		p.lo |= values[i]
+	}
+	var b [16]byte
+	g.read(b[:])
+	r := u128{binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])}.shr(128 - l.random)
+	p = p.shl(l.random)
+	p.hi, p.lo = p.hi|r.hi, p.lo|r.lo
+	return p.v8(), true
+}
+
+// Decode returns the field values of u in l's order. It returns nil and false for non-v8 UUIDs. l cannot tell
+// whether a v8 was encoded with it, make sure it was (e.g. by a field holding a layout tag).
+func (l *Layout) Decode(u UUID) ([]uint64, bool) {
+	if u.Version() != Version8 {
+		return nil, false
+	}
+	p, off := payload(u), payloadBits
+	values := make([]uint64, len(l.fields))
+	for i, f := range l.fields {
+		off -= f.Bits
+		values[i] = p.shr(off).lo & mask(f.Bits)
+	}
+	return values, true
+}
+
+// Get returns the value of the field name in u. It returns 0 and false for non-v8 UUIDs and unknown names.
+func (l *Layout) Get(u UUID, name string) (uint64, bool) {
+	if u.Version() != Version8 {
+		return 0, false
+	}
+	off := payloadBits
+	for _, f := range l.fields {
+		off -= f.Bits
+		if f.Name == name {
+			return payload(u).shr(off).lo & mask(f.Bits), true
+		}
+	}
+	return 0, false
+}
+
+// u128 is an unsigned 128 bit integer.
+type u128 struct{ hi, lo uint64 }
+
+// returns x shifted left by 0 <= n < 128 bits.
+func (x u128) shl(n int) u128 {
+	if n >= 64 {
+		return u128{x.lo << (n - 64), 0}
+	}
+	return u128{x.hi<<n | x.lo>>(64-n), x.lo << n}
+}
+
+// returns x shifted right by 0 <= n < 128 bits.
+func (x u128) shr(n int) u128 {
+	if n >= 64 {
+		return u128{0, x.hi >> (n - 64)}
+	}
+	return u128{x.hi >> n, x.lo>>n | x.hi<<(64-n)}
+}
+
+// returns the v8 UUID with payload x (the low 122 bits).
+func (x u128) v8() UUID {
+	return NewV8Fields(x.shr(payloadBits-48).lo, uint16(x.shr(customCBits).lo), x.lo) //nolint:gosec // masked
+}
+
+// returns the 122 bit payload of v8 u.
+func payload(u UUID) u128 {
+	p := u128{0, u.CustomA()}.shl(12)
+	p.lo |= uint64(u.CustomB())
+	p = p.shl(customCBits)
+	p.lo |= u.CustomC()
+	return p
+}
+
+func mask(bits int) uint64 { return 1<<bits - 1 }
@@ -0,0 +1,121 @@
+package uid_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"testing/iotest"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayout(t *testing.T) {
+	l := uid.NewLayout(
+		uid.Field{Name: "tenant", Bits: 20},
+		uid.Field{Name: "hour", Bits: 24},
+		uid.Field{Name: "shard", Bits: 10},
+	)
+	id, ok := l.Encode(0xabcde, 0x123456, 0x3ff)
+	assert.True(t, ok)
+	assert.Exactly(t, uid.Version8, id.Version())
+	assert.Exactly(t, uid.Variant9562, id.Variant())
+	values, ok := l.Decode(id)
+	assert.True(t, ok)
+	assert.Exactly(t, []uint64{0xabcde, 0x123456, 0x3ff}, values)
+	shard, ok := l.Get(id, "shard")
+	assert.True(t, ok)
+	assert.Exactly(t, uint64(0x3ff), shard)
+	_, ok = l.Get(id, "nope")
+	assert.False(t, ok)
+	// fields pack from the top, version bits skipped
+	assert.Exactly(t, uint64(0xabcde123456), id.CustomA()>>4)
+	// remaining 68 bits are random
+	other, _ := l.Encode(0xabcde, 0x123456, 0x3ff)
+	assert.NotEqual(t, id, other)
+	// round trips through Parse
+	for _, s := range []string{id.String(), id.Compact32(), id.Compact64(), string(id.Bytes())} {
+		parsed, ok := uid.Parse(s)
+		assert.True(t, ok)
+		values, ok = l.Decode(parsed)
+		assert.True(t, ok)
+		assert.Exactly(t, []uint64{0xabcde, 0x123456, 0x3ff}, values)
+	}
+	assert.Exactly(t, []uid.Field{{"tenant", 20}, {"hour", 24}, {"shard", 10}}, l.Fields())
+}
+
+func TestLayoutOverflow(t *testing.T) {
+	l := uid.NewLayout(uid.Field{Name: "a", Bits: 4}, uid.Field{Name: "b", Bits: 64})
+	_, ok := l.Encode(16, 0)
+	assert.False(t, ok)
+	_, ok = l.Encode(15)
+	assert.False(t, ok)
+	_, ok = l.Encode(15, 1<<64-1, 0)
+	assert.False(t, ok)
+	id, ok := l.Encode(15, 1<<64-1)
+	assert.True(t, ok)
+	values, _ := l.Decode(id)
+	assert.Exactly(t, []uint64{15, 1<<64 - 1}, values)
+	// non-v8
+	_, ok = l.Decode(uid.NewV4())
+	assert.False(t, ok)
+	_, ok = l.Get(uid.NewV7(), "a")
+	assert.False(t, ok)
+}
+
+func TestLayoutFull(t *testing.T) {
+	// every one of the 122 bits, across the version and variant gaps
+	l := uid.NewLayout(uid.Field{Name: "a", Bits: 47}, uid.Field{Name: "b", Bits: 14},
+		uid.Field{Name: "c", Bits: 61})
+	for _, values := range [][]uint64{{0, 0, 0}, {1<<47 - 1, 1<<14 - 1, 1<<61 - 1}, {1, 1, 1}, {0x5555, 0x2aaa, 0x123}} {
+		id, ok := l.Encode(values...)
+		assert.True(t, ok)
+		assert.Exactly(t, uid.Version8, id.Version())
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+		got, _ := l.Decode(id)
+		assert.Exactly(t, values, got)
+	}
+	zero, _ := l.Encode(0, 0, 0)
+	assert.Exactly(t, "00000000-0000-8000-8000-000000000000", zero.String()) // nothing left to randomness
+}
+
+func TestLayoutSorts(t *testing.T) {
+	l := uid.NewLayout(uid.Field{Name: "tenant", Bits: 16}, uid.Field{Name: "seq", Bits: 40})
+	var ids []uid.UUID
+	for tenant := range uint64(20) {
+		for seq := range uint64(20) {
+			id, _ := l.Encode(tenant, seq<<30)
+			ids = append(ids, id)
+		}
+	}
+	assert.True(t, slices.IsSortedFunc(ids, uid.CompareAll))
+}
+
+func TestLayoutPanics(t *testing.T) {
+	msg := "uid: layout fields must be uniquely named and 1 to 64 bits wide"
+	assert.PanicsWithValue(t, msg, func() { uid.NewLayout(uid.Field{Name: "a", Bits: 0}) })
+	assert.PanicsWithValue(t, msg, func() { uid.NewLayout(uid.Field{Name: "a", Bits: 65}) })
+	assert.PanicsWithValue(t, msg, func() { uid.NewLayout(uid.Field{Name: "a", Bits: 1}, uid.Field{Name: "a", Bits: 1}) })
+	assert.PanicsWithValue(t, "uid: layout fields must fit in 122 bits", func() {
+		uid.NewLayout(uid.Field{Name: "a", Bits: 64}, uid.Field{Name: "b", Bits: 59})
+	})
+}
+
+func TestLayoutGenerator(t *testing.T) {
+	// the random bits come from the Generator, so seeded ones encode reproducibly
+	l := uid.NewLayout(uid.Field{Name: "tenant", Bits: 20})
+	a, ok := uid.NewGenerator(uid.WithSeed([32]byte{1})).Encode(l, 42)
+	assert.True(t, ok)
+	b, _ := uid.NewGenerator(uid.WithSeed([32]byte{1})).Encode(l, 42)
+	assert.Exactly(t, a, b)
+	tenant, _ := l.Get(a, "tenant")
+	assert.Exactly(t, uint64(42), tenant)
+	_, ok = uid.NewGenerator().Encode(l, 1<<20)
+	assert.False(t, ok)
+	// only the package form draws from the default Generator
+	g := uid.NewGenerator(uid.WithEntropy(iotest.ErrReader(errors.New("broken"))))
+	defer uid.SetDefault(uid.NewGenerator())
+	uid.SetDefault(g)
+	assert.NotPanics(t, func() { _, _ = uid.NewGenerator().Encode(l, 1) })
+	assert.Panics(t, func() { _, _ = l.Encode(1) })
+}
@@ -0,0 +1,54 @@
+package uid
+
+import (
+	"encoding/binary"
+)
+
+const maxIncrement = 1 << 32 // upper bound of the random increment between V7MonotonicRandom IDs in the same ms
+
+// monoRandom is the state of RFC9562 method 2 (monotonic random) generation.
+type monoRandom struct {
+	mrMs int64  // unix_ts_ms of the last issued ID
+	mrA  uint64 // last issued rand_a (12 bits)
+	mrB  uint64 // last issued rand_b (62 bits)
+}
+
+/*
+makeMonotonicRandom builds a method 2 v7. The first ID of a ms gets fresh random rand_a and rand_b (with rand_b's top bit
+clear as an overflow guard). Every further ID within the ms increments rand_b by a random amount in [1, 2^32], carrying
+into rand_a when the 62 bits of rand_b are exhausted. A node (see WithNode) takes the top of rand_b, shrinking the
+incremented field and the increments with it. When rand_a is exhausted too the timestamp is advanced by one ms
+(ahead of the clock if need be) and the random fields are reseeded. A clock that moves backwards is treated as still
+being in the last ms. IDs are strictly increasing by CompareAll.
+*/
+//nolint:mnd // locality of behavior
+func (g *Generator) makeMonotonicRandom() UUID {
+	var b [16]byte
+	g.read(b[:])
+	seed, inc := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	field := uint64(randBMask) >> g.nodeBits
+	ms := g.tick().ms()
+	g.mu.Lock()
+	switch {
+	case ms > g.mrMs:
+		g.mrMs, g.mrA, g.mrB = ms, seed>>52, inc&(field>>1)
+	default:
+		g.mrB += 1 + inc%min(maxIncrement, field>>16+1)
+		if g.mrB > field { // rand_b exhausted, carry
+			g.mrB &= field
+			g.mrA++
+		}
+		if g.mrA == scale { // rand_a exhausted too, roll into the next ms
+			g.mrMs, g.mrA, g.mrB = min(g.mrMs+1, maxMs), seed>>52, inc&(field>>1)
+		}
+	}
+	ms, ra, rb := g.mrMs, g.mrA, g.mrB
+	g.mu.Unlock()
+	put7(&b, stamp(ms)<<12|stamp(ra))
+	binary.BigEndian.PutUint64(b[8:], rb|0x8000_0000_0000_0000) // variant
+	g.putNode(&b)
+	if g.stats != nil {
+		g.stats.v7.Add(1)
+	}
+	return UUID{b}
+}
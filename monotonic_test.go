@@ -0,0 +1,68 @@
+package uid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV7MonotonicRandom(t *testing.T) {
+	g := uid.NewGenerator(uid.WithV7Mode(uid.V7MonotonicRandom))
+	prev := g.NewV7()
+	assert.Exactly(t, uid.Version7, prev.Version())
+	assert.Exactly(t, uid.Variant9562, prev.Variant())
+	id, ok := uid.Parse(prev.String())
+	assert.True(t, ok)
+	assert.Exactly(t, prev, id)
+	// millions of IDs, mostly sharing ms with their predecessor
+	const count = 2 * 1000 * 1000
+	unordered, ties := 0, 0
+	for range count {
+		id := g.NewV7()
+		if uid.CompareAll(prev, id) != -1 || uid.Compare(prev, id) == 1 {
+			unordered++
+		}
+		if uid.Compare(prev, id) == 0 {
+			ties++
+		}
+		prev = id
+	}
+	assert.Zero(t, unordered)
+	assert.Positive(t, ties) // ordering within a ms comes from rand_b
+}
+
+func TestV7MonotonicRandomFrozenClock(t *testing.T) {
+	freezeNow := time.Now()
+	g := uid.NewGenerator(uid.WithV7Mode(uid.V7MonotonicRandom), uid.WithClock(func() time.Time { return freezeNow }))
+	prev := g.NewV7()
+	for range 100_000 {
+		id := g.NewV7()
+		assert.Exactly(t, -1, uid.CompareAll(prev, id))
+		prev = id
+	}
+	assert.Exactly(t, freezeNow.Truncate(time.Millisecond).UnixNano(), prev.TimeFor(uid.V7MonotonicRandom).UnixNano())
+}
+
+func TestV7MonotonicRandomOverflow(t *testing.T) {
+	freezeNow := time.Now()
+	g := uid.NewGenerator(uid.WithV7Mode(uid.V7MonotonicRandom), uid.WithClock(func() time.Time { return freezeNow }))
+	ms := freezeNow.UnixMilli()
+	// rand_b exhausted carries into rand_a
+	uid.SetMonotonicRandom(g, ms, 7, 1<<62-1)
+	id := g.NewV7()
+	assert.Exactly(t, ms, id.Time().UnixMilli())
+	assert.Exactly(t, byte(0x08), id.Bytes()[7]) // rand_a 7 -> 8
+	assert.Exactly(t, uid.Variant9562, id.Variant())
+	// rand_a and rand_b exhausted rolls into the next ms
+	uid.SetMonotonicRandom(g, ms, 4095, 1<<62-1)
+	prev := uid.NewV7At(freezeNow.Truncate(time.Millisecond).Add(time.Millisecond - time.Nanosecond))
+	id = g.NewV7()
+	assert.Exactly(t, ms+1, id.Time().UnixMilli())
+	assert.Exactly(t, -1, uid.CompareAll(prev, id))
+	// clock going backwards holds the last ms
+	next := g.NewV7()
+	assert.Exactly(t, ms+1, next.Time().UnixMilli())
+	assert.Exactly(t, -1, uid.CompareAll(id, next))
+}
@@ -0,0 +1,68 @@
+package uid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"os"
+)
+
+const maxNodeBits = 32
+
+//nolint:gochecknoglobals // manipulatable via functions in export_test
+var (
+	machineIDPath = "/etc/machine-id"
+	hostname      = os.Hostname
+)
+
+// WithNode reserves the top bits of every v7 rand_b the Generator mints for id, so IDs from different nodes (replicas,
+// workers) can never collide and can be attributed with UUID.Node. Panics unless 1 <= bits <= 32 and id fits in bits.
+// See MachineNode to derive id from the host.
+func WithNode(id uint64, bits int) Option {
+	if bits < 1 || bits > maxNodeBits || id >= 1<<bits {
+		panic("uid: node must fit in 1 to 32 bits")
+	}
+	return func(g *Generator) { g.node, g.nodeBits = id, bits }
+}
+
+// MachineNode derives a bits wide node ID by hashing /etc/machine-id, falling back to the hostname and finally to
+// crypto/rand when neither is available. Panics unless 1 <= bits <= 32, like WithNode.
+func MachineNode(bits int) uint64 {
+	if bits < 1 || bits > maxNodeBits {
+		panic("uid: node must fit in 1 to 32 bits")
+	}
+	id, err := os.ReadFile(machineIDPath)
+	if id = bytes.TrimSpace(id); err != nil || len(id) == 0 {
+		name, err := hostname()
+		if err != nil || name == "" {
+			var seed [8]byte
+			if _, err := cryptoRead(seed[:]); err != nil {
+				panic("unable to initialize node from crypto/rand") // untestable
+			}
+			return binary.BigEndian.Uint64(seed[:]) & (1<<bits - 1)
+		}
+		id = []byte(name)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(id) //nolint:errcheck // never returns errors
+	return h.Sum64() & (1<<bits - 1)
+}
+
+// Node returns the top bits of u's rand_b, the node ID of a v7 minted by a Generator configured WithNode(_, bits).
+// For non-V7 0 is returned.
+func (u UUID) Node(bits int) uint64 {
+	if u.Version() != Version7 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(u.b[8:]) & randBMask >> (randBBits - min(max(bits, 0), randBBits))
+}
+
+// stamps g's node into the top of b's rand_b.
+func (g *Generator) putNode(b *[16]byte) {
+	if g.nodeBits == 0 {
+		return
+	}
+	shift := randBBits - g.nodeBits
+	rb := binary.BigEndian.Uint64(b[8:])
+	binary.BigEndian.PutUint64(b[8:], rb&^((1<<g.nodeBits-1)<<shift)|g.node<<shift)
+}
@@ -0,0 +1,97 @@
+package uid_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode(t *testing.T) {
+	check := func(bits int, node uint64, opts ...uid.Option) {
+		g := uid.NewGenerator(append(opts, uid.WithNode(node, bits))...)
+		ids := make([]uid.UUID, 300)
+		g.FillV7(ids[:100])
+		for i := 100; i < 200; i++ {
+			ids[i] = g.NewV7()
+		}
+		for i := 200; i < 300; i++ {
+			ids[i] = g.NewV7Strict()
+		}
+		for _, id := range ids {
+			assert.Exactly(t, uid.Version7, id.Version())
+			assert.Exactly(t, uid.Variant9562, id.Variant())
+			assert.Exactly(t, node, id.Node(bits))
+		}
+	}
+	check(1, 1)
+	check(8, 0xa5)
+	check(16, 0)
+	check(32, 1<<32-1)
+	check(10, 0x2aa, uid.WithV7Mode(uid.V7Strict))
+	check(10, 0x2aa, uid.WithV7Mode(uid.V7Counter))
+	check(32, 0xdeadbeef, uid.WithV7Mode(uid.V7Counter), uid.WithCounterBits(42))
+	check(10, 0x2aa, uid.WithV7Mode(uid.V7MonotonicRandom))
+	check(32, 0xdeadbeef, uid.WithV7Mode(uid.V7MonotonicRandom))
+	ones, ok := uid.Parse("ffffffff-ffff-7fff-bfff-ffffffffffff")
+	assert.True(t, ok)
+	assert.Exactly(t, uint64(0), ones.Node(0))
+	assert.Exactly(t, uint64(1<<62-1), ones.Node(99))
+	// only v7 carry a node
+	assert.Exactly(t, uint64(0), uid.Max().Node(8))
+	assert.Exactly(t, uint64(0), uid.Nil().Node(8))
+	assert.Exactly(t, uint64(0), uid.NewV4().Node(8))
+}
+
+func TestNodeKeepsOrdering(t *testing.T) {
+	freezeNow := time.Now()
+	check := func(opts ...uid.Option) {
+		g := uid.NewGenerator(append(opts, uid.WithClock(func() time.Time { return freezeNow }))...)
+		ids := make([]uid.UUID, 20_000)
+		for i := range ids {
+			ids[i] = g.NewV7()
+		}
+		assert.True(t, slices.IsSortedFunc(ids, uid.CompareAll))
+		assert.Len(t, slices.Compact(ids), len(ids))
+	}
+	check(uid.WithNode(0xdeadbeef, 32), uid.WithV7Mode(uid.V7Counter), uid.WithCounterBits(42))
+	check(uid.WithNode(0xdeadbeef, 32), uid.WithV7Mode(uid.V7MonotonicRandom))
+	check(uid.WithNode(7, 3), uid.WithV7Mode(uid.V7Strict))
+}
+
+func TestWithNodeBounds(t *testing.T) {
+	assert.Panics(t, func() { uid.WithNode(0, 0) })
+	assert.Panics(t, func() { uid.WithNode(0, 33) })
+	assert.Panics(t, func() { uid.WithNode(256, 8) })
+	assert.NotPanics(t, func() { uid.WithNode(255, 8) })
+	msg := "uid: node must fit in 1 to 32 bits"
+	assert.PanicsWithValue(t, msg, func() { uid.MachineNode(-1) })
+	assert.PanicsWithValue(t, msg, func() { uid.MachineNode(0) })
+	assert.PanicsWithValue(t, msg, func() { uid.MachineNode(33) })
+	assert.NotPanics(t, func() { uid.WithNode(uid.MachineNode(32), 32) })
+}
+
+func TestMachineNode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "machine-id")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789abcdef0123456789abcdef\n"), 0o600))
+	noHost := func() (string, error) { return "", os.ErrNotExist }
+	// machine-id
+	defer uid.SetMachineSources(path, noHost)()
+	fromFile := uid.MachineNode(16)
+	assert.Less(t, fromFile, uint64(1<<16))
+	assert.Exactly(t, fromFile, uid.MachineNode(16)) // stable
+	// hostname fallback
+	uid.SetMachineSources(filepath.Join(dir, "missing"), func() (string, error) { return "worker-1", nil })
+	fromHost := uid.MachineNode(16)
+	assert.Exactly(t, fromHost, uid.MachineNode(16))
+	assert.NotEqual(t, fromFile, fromHost)
+	// random fallback
+	uid.SetMachineSources(filepath.Join(dir, "missing"), noHost)
+	assert.Less(t, uid.MachineNode(4), uint64(1<<4))
+}
@@ -9,8 +9,9 @@ import (
 	"unicode"
 )
 
-// Parse attempts to parse `src` into a UUID and returns the parsed UUID and `true` on success.
-// On failure, Parse returns the Nil UUID and `false`.
+// Parse attempts to parse `src` into a UUID and returns the parsed UUID and `true` on success. Every RFC9562 version
+// (1 through 8, with the RFC9562 variant) is accepted, as are Nil and Max. On failure, Parse returns the Nil UUID and
+// `false`. See ParseStrict to accept only v4 and v7.
 //
 //nolint:mnd // locality of behavior
 func Parse(src string) (UUID, bool) {
@@ -37,6 +38,16 @@ func Parse(src string) (UUID, bool) {
 	return UUID{}, false
 }
 
+// ParseStrict is Parse accepting only v4, v7, Nil and Max UUIDs, the versions this library generates for new IDs.
+func ParseStrict(src string) (UUID, bool) {
+	id, ok := Parse(src)
+	switch id.Version() { //nolint:exhaustive // golf
+	case Version4, Version7, VersionNil, VersionMax:
+		return id, ok
+	}
+	return UUID{}, false
+}
+
 //nolint:cyclop // parsers...
 func canonicalV(s string) Version {
 	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
@@ -44,15 +55,10 @@ func canonicalV(s string) Version {
 	}
 	varR := rune(s[19])
 	switch rune(s[14]) {
-	case '4':
-		switch varR {
-		case '8', '9', 'A', 'a', 'b', 'B':
-			return Version4
-		}
-	case '7':
+	case '1', '2', '3', '4', '5', '6', '7', '8':
 		switch varR {
 		case '8', '9', 'A', 'a', 'b', 'B':
-			return Version7
+			return Version(s[14] - '0')
 		}
 	case '0':
 		if s == NilCanonical {
@@ -69,7 +75,7 @@ func canonicalV(s string) Version {
 func bytesV(b []byte) Version {
 	vrsn := Version(b[6] >> 4) //nolint:mnd // lob
 	switch vrsn {              //nolint:exhaustive // golf
-	case Version4, Version7:
+	case Version1, Version2, Version3, Version4, Version5, Version6, Version7, Version8:
 		if Variant(b[8]>>6) == Variant9562 { //nolint:mnd // lob
 			return vrsn
 		}
@@ -88,15 +94,10 @@ func bytesV(b []byte) Version {
 func ncn64V(s string) Version {
 	varR := rune(s[21])
 	switch rune(s[0]) {
-	case 'E':
+	case 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I':
 		switch varR {
 		case 'I', 'J', 'K', 'L':
-			return Version4
-		}
-	case 'H':
-		switch varR {
-		case 'I', 'J', 'K', 'L':
-			return Version7
+			return Version(s[0] - 'A')
 		}
 	case 'A':
 		if s == NilCompact64 {
@@ -113,15 +114,10 @@ func ncn64V(s string) Version {
 func ncn32V(s string) Version {
 	varR := rune(s[25])
 	switch rune(s[0]) {
-	case 'E', 'e':
+	case 'B', 'b', 'C', 'c', 'D', 'd', 'E', 'e', 'F', 'f', 'G', 'g', 'H', 'h', 'I', 'i':
 		switch varR {
 		case 'i', 'I', 'j', 'J', 'k', 'K', 'l', 'L':
-			return Version4
-		}
-	case 'H', 'h':
-		switch varR {
-		case 'i', 'I', 'j', 'J', 'k', 'K', 'l', 'L':
-			return Version7
+			return Version(s[0]&^0x20 - 'A') //nolint:mnd // upper case
 		}
 	case 'A', 'a':
 		if strings.ToUpper(s) == NilCompact32 {
@@ -185,7 +181,7 @@ func parseCompact32(src string) (UUID, bool) {
 	if v == VersionMax {
 		return UUID{bytesMax}, true
 	}
-	// not Nil or Max, decode with padding v4/v7
+	// not Nil or Max, decode with padding
 	var out UUID
 	_, err := b32decoder.Decode(out.b[:], []byte(strings.ToUpper(src) + "A")[1:])
 	if err != nil {
@@ -82,7 +82,7 @@ func TestParseCanonicalBad(t *testing.T) {
 		assertBadTxt(t, bad)
 		// bad version
 		bad = []rune(ref) // reset
-		bad[14] = '1'     // uuid v1 not supported
+		bad[14] = '9'     // uuid v9 not defined
 		assertBadTxt(t, bad)
 		// bad variant
 		bad = []rune(ref) // reset
@@ -116,7 +116,7 @@ func TestParseCompact32Bad(t *testing.T) {
 	checkFail := func(ref string) {
 		// bad version
 		bad := []rune(ref) // reset
-		bad[0] = 'b'       // uuid v1 not supported
+		bad[0] = 'j'       // uuid v9 not defined
 		assertBadTxt(t, bad)
 		// bad variant
 		bad = []rune(ref) // reset
@@ -147,7 +147,7 @@ func TestParseCompact64Bad(t *testing.T) {
 	checkFail := func(ref string) {
 		// bad version
 		bad := []rune(ref)
-		bad[0] = 'B' // uuid v1 not supported
+		bad[0] = 'J' // uuid v9 not defined
 		assertBadTxt(t, bad)
 		// bad variant
 		bad = []rune(ref)
@@ -181,3 +181,48 @@ func TestParseCompact64CaseSensitive(t *testing.T) {
 }
 
 func TestParseBadLen(t *testing.T) { assertBadTxt(t, []rune{}) }
+
+func TestParseAllVersions(t *testing.T) {
+	for v := range 16 {
+		b := make([]byte, 16)
+		copy(b, ref4Bytes)
+		b[6] = byte(v)<<4 | b[6]&0x0f
+		id, ok := uid.Parse(string(b))
+		assert.Exactly(t, v >= 1 && v <= 8, ok, v)
+		if !ok {
+			continue
+		}
+		assert.Exactly(t, uid.Version(v), id.Version())
+		for _, s := range []string{id.String(), id.Compact32(), strings.ToLower(id.Compact32()), id.Compact64()} {
+			parsed, ok := uid.Parse(s)
+			assert.True(t, ok, s)
+			assert.Exactly(t, id, parsed)
+		}
+		assert.Exactly(t, byte('A'+v), id.Compact64()[0])
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, s := range []string{ref4, ref4b32, ref4b64, ref7, ref7b32, ref7b64, uid.NilCanonical, uid.MaxCompact64} {
+		strict, ok := uid.ParseStrict(s)
+		assert.True(t, ok, s)
+		lax, _ := uid.Parse(s)
+		assert.Exactly(t, lax, strict)
+	}
+	for _, id := range []uid.UUID{
+		uid.NewV5(uid.NamespaceDNS, nil), uid.NewV3(uid.NamespaceDNS, nil), uid.NewV8([16]byte{}),
+	} {
+		for _, s := range []string{id.String(), id.Compact32(), id.Compact64(), string(id.Bytes())} {
+			strict, ok := uid.ParseStrict(s)
+			assert.False(t, ok, s)
+			assert.Exactly(t, uid.Nil(), strict)
+		}
+	}
+	// v1 was never supported strictly
+	bad := []rune(ref4)
+	bad[14] = '1'
+	_, ok := uid.ParseStrict(string(bad))
+	assert.False(t, ok)
+	_, ok = uid.ParseStrict("nope")
+	assert.False(t, ok)
+}
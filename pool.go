@@ -0,0 +1,99 @@
+package uid
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+/*
+Pool hands out v4 UUIDs pre-generated by a background goroutine into a buffer, taking generation (entropy draws)
+off latency-critical paths. v7 is deliberately not offered, prefetched timestamps would be stale by the time they are
+handed out. Buffered IDs generated before the Generator was reseeded (see Generator.Reseed) are discarded instead of
+handed out, so clones of a VM snapshot do not share them. A Pool is safe for concurrent use and must be constructed
+with NewPool, then Closed to stop its goroutine.
+*/
+type Pool struct {
+	gen  *Generator
+	ids  chan pooled
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+
+	hits, misses, refills, stale atomic.Uint64
+}
+
+// pooled is a buffered ID and the Generator reseed epoch it was generated in.
+type pooled struct {
+	id    UUID
+	epoch uint64
+}
+
+// PoolStats counts how a Pool's IDs were served.
+type PoolStats struct {
+	Hits    uint64 // IDs served from the buffer
+	Misses  uint64 // IDs generated inline because the buffer was empty (or the Pool closed)
+	Refills uint64 // batches the background goroutine generated
+	Stale   uint64 // buffered IDs discarded because the Generator was reseeded after generating them
+}
+
+// NewPool starts a Pool buffering up to depth v4 UUIDs from the default Generator. Panics unless depth >= 1.
+func NewPool(depth int) *Pool { return std.NewPool(depth) }
+
+// NewPool starts a Pool buffering up to depth v4 UUIDs from g. Panics unless depth >= 1.
+func (g *Generator) NewPool(depth int) *Pool {
+	if depth < 1 {
+		panic("uid: pool depth must be at least 1")
+	}
+	p := &Pool{gen: g, ids: make(chan pooled, depth), done: make(chan struct{})}
+	p.wg.Add(1)
+	go p.refill(min(depth, chunk))
+	return p
+}
+
+// keeps the buffer topped up, generating batch IDs at a time with FillV4.
+func (p *Pool) refill(batch int) {
+	defer p.wg.Done()
+	buf := make([]UUID, batch)
+	for {
+		epoch := p.gen.epoch.Load() // before generating, a reseed racing the batch marks it stale
+		p.gen.FillV4(buf)
+		p.refills.Add(1)
+		for _, id := range buf {
+			select {
+			case p.ids <- pooled{id, epoch}:
+			case <-p.done:
+				return
+			}
+		}
+	}
+}
+
+// NewV4 returns a buffered v4 UUID, or generates one inline when the buffer is empty. Never blocks.
+func (p *Pool) NewV4() UUID {
+	for {
+		select {
+		case e := <-p.ids:
+			if e.epoch != p.gen.epoch.Load() {
+				p.stale.Add(1)
+				continue
+			}
+			p.hits.Add(1)
+			return e.id
+		default:
+			p.misses.Add(1)
+			return p.gen.NewV4()
+		}
+	}
+}
+
+// Close stops the background goroutine and waits for it to exit. IDs still buffered are served, after that NewV4
+// generates inline. Safe to call more than once.
+func (p *Pool) Close() {
+	p.once.Do(func() { close(p.done) })
+	p.wg.Wait()
+}
+
+// Stats returns a snapshot of p's counters.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{Hits: p.hits.Load(), Misses: p.misses.Load(), Refills: p.refills.Load(), Stale: p.stale.Load()}
+}
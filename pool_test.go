@@ -0,0 +1,87 @@
+package uid_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	p := uid.NewPool(64)
+	defer p.Close()
+	assert.Eventually(t, func() bool { return p.Stats().Refills > 0 }, time.Second, time.Millisecond)
+	seen := make(map[uid.UUID]bool)
+	for range 1000 {
+		id := p.NewV4()
+		assert.Exactly(t, uid.Version4, id.Version())
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+		assert.False(t, seen[id])
+		seen[id] = true
+	}
+	stats := p.Stats()
+	assert.Exactly(t, uint64(1000), stats.Hits+stats.Misses)
+	assert.NotZero(t, stats.Hits)
+}
+
+func TestPoolClose(t *testing.T) {
+	g := uid.NewGenerator(uid.WithSeed([32]byte{1}))
+	p := g.NewPool(8)
+	assert.Eventually(t, func() bool { return p.Stats().Refills > 1 }, time.Second, time.Millisecond) // buffer full
+	p.Close()
+	p.Close()
+	refills := p.Stats().Refills
+	for range 16 { // drains what's buffered, then generates inline
+		assert.Exactly(t, uid.Version4, p.NewV4().Version())
+	}
+	stats := p.Stats()
+	assert.Exactly(t, refills, stats.Refills)
+	assert.Exactly(t, uint64(8), stats.Hits)
+	assert.Exactly(t, uint64(8), stats.Misses)
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	p := uid.NewPool(16)
+	defer p.Close()
+	var mu sync.Mutex
+	seen := make(map[uid.UUID]bool)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				id := p.NewV4()
+				mu.Lock()
+				assert.False(t, seen[id])
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 4000)
+}
+
+func TestPoolReseed(t *testing.T) {
+	// two clones resumed from one snapshot: identical generator state, identical buffers
+	a, b := uid.NewGenerator(uid.WithSeed([32]byte{3})), uid.NewGenerator(uid.WithSeed([32]byte{3}))
+	pa, pb := a.NewPool(64), b.NewPool(64)
+	defer pa.Close()
+	defer pb.Close()
+	assert.Eventually(t, func() bool { return pa.Stats().Refills > 1 && pb.Stats().Refills > 1 }, time.Second,
+		time.Millisecond) // buffers full
+	a.Reseed()
+	b.Reseed()
+	for range 200 {
+		assert.NotEqual(t, pa.NewV4(), pb.NewV4())
+	}
+	assert.GreaterOrEqual(t, pa.Stats().Stale, uint64(64))
+	assert.GreaterOrEqual(t, pb.Stats().Stale, uint64(64))
+}
+
+func TestPoolDepthPanics(t *testing.T) {
+	assert.PanicsWithValue(t, "uid: pool depth must be at least 1", func() { uid.NewPool(0) })
+}
@@ -0,0 +1,56 @@
+package uid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+/*
+WithPrecision reduces the timestamp precision of the Generator's NewV7 and NewV7At to d (e.g. time.Second, time.Minute,
+time.Hour) for IDs that should not reveal exactly when they were created. unix_ts_ms is truncated to a multiple of d
+and offset by a random amount within it, rand_a is random, so IDs still sort by time at d's granularity but not within
+it. Read times back with UUID.TimeWithin(d). Panics unless d is a positive whole number of milliseconds. Only allowed
+with V7Method3, NewGenerator panics for other modes. The strict orderings need full precision, so rather than leak it
+NewV7Strict, NewV7StrictAt, FillV7 and SeqV7 return reduced-precision IDs like NewV7, TryNewV7Strict returns false and
+NewV7StrictContext an error.
+*/
+func WithPrecision(d time.Duration) Option {
+	if d < time.Millisecond || d%time.Millisecond != 0 {
+		panic("uid: precision must be a positive whole number of milliseconds")
+	}
+	return func(g *Generator) { g.precision = d.Milliseconds() }
+}
+
+// makeCoarse builds a v7 for the ms granule of g's precision containing ms.
+//
+//nolint:mnd // locality of behavior
+func (g *Generator) makeCoarse(ms int64) UUID {
+	var buf [24]byte
+	g.read(buf[:])
+	off := binary.BigEndian.Uint64(buf[16:])
+	ms = min(ms-ms%g.precision+int64(off%uint64(g.precision)), maxMs) //nolint:gosec // precision is positive
+	b := [16]byte(buf[:16])
+	b[0], b[1], b[2], b[3], b[4], b[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	// version, variant, rand_a and rand_b stay random
+	b[6], b[8] = (b[6]&0x0f)|0x70, (b[8]&0x3f)|0x80
+	g.putNode(&b)
+	if g.stats != nil {
+		g.stats.v7.Add(1)
+	}
+	return UUID{b}
+}
+
+// TimeWithin returns the embedded timestamp of a v7 UUID truncated to precision, the granularity a UUID from a
+// WithPrecision Generator can vouch for. Whole millisecond precisions count from the Unix epoch, others truncate like
+// time.Time.Truncate. For non-V7 zero(time.Time) is returned.
+func (u UUID) TimeWithin(precision time.Duration) time.Time {
+	t := u.Time()
+	switch {
+	case t.IsZero() || precision <= 0:
+		return t
+	case precision%time.Millisecond == 0:
+		ms, p := u.stamp().ms(), precision.Milliseconds()
+		return time.UnixMilli(ms - ms%p)
+	}
+	return t.Truncate(precision)
+}
@@ -0,0 +1,115 @@
+package uid_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrecision(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 34, 56, 789_000_000, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return now }), uid.WithPrecision(time.Minute))
+	minute := time.Date(2024, 6, 1, 12, 34, 0, 0, time.UTC)
+	offsets, slots := map[time.Duration]bool{}, map[time.Duration]bool{}
+	for range 100 {
+		id := g.NewV7()
+		assert.Exactly(t, uid.Version7, id.Version())
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+		assert.Exactly(t, minute, id.TimeWithin(time.Minute).UTC())
+		ts := id.Time().Sub(minute)
+		assert.True(t, ts >= 0 && ts < time.Minute)
+		offsets[ts.Truncate(time.Millisecond)] = true
+		slots[ts%time.Millisecond] = true
+	}
+	// both the truncated ms and rand_a are random, not the clock's
+	assert.Greater(t, len(offsets), 90)
+	assert.Greater(t, len(slots), 90)
+	assert.Exactly(t, minute, g.NewV7At(now).TimeWithin(time.Minute).UTC())
+}
+
+func TestPrecisionSorts(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return now }), uid.WithPrecision(time.Second))
+	var ids []uid.UUID
+	for range 50 {
+		for range 20 {
+			ids = append(ids, g.NewV7())
+		}
+		now = now.Add(time.Second)
+	}
+	// sorted by second, not within it
+	for i := 20; i < len(ids); i++ {
+		assert.Exactly(t, -1, uid.Compare(ids[i-20], ids[i]))
+	}
+	assert.False(t, slices.IsSortedFunc(ids, uid.Compare))
+}
+
+func TestPrecisionHold(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 30, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return now }), uid.WithPrecision(time.Minute),
+		uid.WithClockPolicy(uid.ClockHold, nil))
+	first := g.NewV7().TimeWithin(time.Minute)
+	now = now.Add(-time.Hour)
+	assert.Exactly(t, first, g.NewV7().TimeWithin(time.Minute))
+}
+
+func TestPrecisionOptions(t *testing.T) {
+	assert.PanicsWithValue(t, "uid: precision must be a positive whole number of milliseconds",
+		func() { uid.WithPrecision(time.Microsecond) })
+	assert.PanicsWithValue(t, "uid: precision must be a positive whole number of milliseconds",
+		func() { uid.WithPrecision(1500 * time.Microsecond) })
+	assert.PanicsWithValue(t, "uid: WithPrecision requires V7Method3", func() {
+		uid.NewGenerator(uid.WithPrecision(time.Second), uid.WithV7Mode(uid.V7Strict))
+	})
+	// year 10889 clamps, the last granule may be cut short
+	limit := time.UnixMilli(1<<48 - 1)
+	id := uid.NewGenerator(uid.WithPrecision(time.Hour)).NewV7At(limit.Add(time.Hour))
+	assert.Exactly(t, uid.NewV7At(limit).TimeWithin(time.Hour), id.TimeWithin(time.Hour))
+	assert.False(t, id.Time().After(limit.Add(time.Millisecond)))
+}
+
+func TestTimeWithin(t *testing.T) {
+	id := uid.NewV7At(time.Date(2024, 6, 1, 12, 34, 56, 789_123_456, time.UTC))
+	assert.Exactly(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), id.TimeWithin(time.Hour).UTC())
+	assert.Exactly(t, time.Date(2024, 6, 1, 12, 34, 56, 789_000_000, time.UTC), id.TimeWithin(time.Millisecond).UTC())
+	assert.Exactly(t, time.Date(2024, 6, 1, 12, 34, 56, 789_100_000, time.UTC),
+		id.TimeWithin(100*time.Microsecond).UTC())
+	assert.Exactly(t, id.Time(), id.TimeWithin(0))
+	assert.True(t, uid.NewV4().TimeWithin(time.Hour).IsZero())
+}
+
+func TestPrecisionStrict(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 34, 56, 789_000_000, time.UTC)
+	hour := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithPrecision(time.Hour), uid.WithClock(func() time.Time { return at }))
+	coarse := func(id uid.UUID) {
+		assert.Exactly(t, uid.Version7, id.Version())
+		assert.Exactly(t, hour, id.TimeWithin(time.Hour).UTC())
+	}
+	coarse(g.NewV7Strict())
+	coarse(g.NewV7StrictAt(at))
+	ids := make([]uid.UUID, 10)
+	g.FillV7(ids)
+	for _, id := range ids {
+		coarse(id)
+	}
+	for id := range g.SeqV7(10) {
+		coarse(id)
+	}
+	id, ok := g.TryNewV7Strict()
+	assert.False(t, ok)
+	assert.Exactly(t, uid.Nil(), id)
+	id, err := g.NewV7StrictContext(context.Background())
+	assert.Error(t, err)
+	assert.Exactly(t, uid.Nil(), id)
+	// the package functions follow the default Generator
+	defer uid.SetDefault(uid.NewGenerator())
+	uid.SetDefault(g)
+	assert.NotPanics(t, func() { coarse(uid.NewV7Strict()) })
+	_, ok = uid.TryNewV7Strict()
+	assert.False(t, ok)
+}
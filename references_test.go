@@ -5,6 +5,7 @@ import (
 	"embed"
 	"encoding/csv"
 	"io"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -53,7 +54,7 @@ var (
 )
 
 func TestParseCompactSamples(t *testing.T) {
-	tested := 0
+	tested, versions := 0, map[uid.Version]int{}
 	for _, sample := range sampleData {
 		c, ok := uid.Parse(sample.Canonical)
 		assert.True(t, ok)
@@ -65,8 +66,9 @@ func TestParseCompactSamples(t *testing.T) {
 		// ensure equivalent parsing
 		assert.Exactly(t, ids[0], ids[1])
 		assert.Exactly(t, ids[1], ids[2])
+		versions[ids[0].Version()]++
 		for _, id := range ids {
-			assert.Exactly(t, uid.Version4, id.Version())
+			assert.Exactly(t, sample.Canonical[14:15], strconv.Itoa(int(id.Version())))
 			assert.Exactly(t, uid.Variant9562, id.Variant())
 			assert.Exactly(t, sample.Canonical, id.String())
 			assert.True(t, strings.EqualFold(sample.B32, id.Compact32()))
@@ -74,5 +76,9 @@ func TestParseCompactSamples(t *testing.T) {
 		}
 		tested++
 	}
-	assert.Exactly(t, 1000, tested)
+	assert.Exactly(t, 1700, tested)
+	assert.Exactly(t, map[uid.Version]int{
+		uid.Version1: 100, uid.Version2: 100, uid.Version3: 100, uid.Version4: 1000, uid.Version5: 100,
+		uid.Version6: 100, uid.Version7: 100, uid.Version8: 100,
+	}, versions)
 }
@@ -0,0 +1,99 @@
+package uid
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const genIDEvery = 100 * time.Millisecond // how often a clone detector rereads the VM generation ID
+
+// Reseed re-keys the default Generator's ChaCha8 generators from crypto/rand. See Generator.Reseed.
+func Reseed() { std.Reseed() }
+
+/*
+Reseed re-keys g's built-in ChaCha8 generators (the default pool, WithSeed, the WithEntropyFallback pool) from
+crypto/rand, e.g. after a VM snapshot was restored or cloned and every clone resumed with identical generator state.
+Pool shards re-key on their next use and IDs buffered by g's Pools are discarded. Other entropy sources are left alone.
+Safe for concurrent use with generation.
+*/
+func (g *Generator) Reseed() {
+	if g.stats != nil {
+		g.stats.reseeds.Add(1)
+	}
+	for _, r := range []any{g.rand, g.backup} {
+		if r, ok := r.(interface{ reseed() }); ok {
+			r.reseed()
+		}
+	}
+	g.epoch.Add(1) // after re-keying, so IDs tagged with the new epoch come from re-keyed sources
+}
+
+/*
+WithCloneDetection makes the Generator Reseed itself when it sees the signs of a VM snapshot being resumed or cloned:
+the wall clock (set by WithClock) jumping more than maxJump ahead of monotonic time, which stands still while a VM is
+paused, or the contents of genIDPath (a file exposing the VM generation ID, ignored when empty or unreadable) changing.
+Checks run before every entropy draw, the generation ID is reread at most every 100ms. report, when non-nil, is called
+after every detection. Off by default.
+*/
+func WithCloneDetection(maxJump time.Duration, genIDPath string, report func()) Option {
+	return func(g *Generator) { g.detector = &cloneDetector{maxJump: maxJump, genPath: genIDPath, report: report} }
+}
+
+// cloneDetector watches for VM snapshot resumption.
+type cloneDetector struct {
+	wall    func() time.Time
+	mono    func() time.Duration // monotonic time elapsed since an arbitrary fixed point
+	maxJump time.Duration
+	offset  atomic.Int64 // wall minus monotonic ns at the last check
+	report  func()
+
+	genPath string
+	genNext atomic.Int64 // monotonic ns of the next generation ID read
+	mu      sync.Mutex   // guards genID
+	genID   []byte
+}
+
+// starts d with wall as its clock.
+func (d *cloneDetector) start(wall func() time.Time) {
+	d.wall = wall
+	if d.mono == nil {
+		start := time.Now()
+		d.mono = func() time.Duration { return time.Since(start) }
+	}
+	m := d.mono()
+	d.offset.Store(wall().UnixNano() - int64(m))
+	if d.genPath != "" {
+		d.genID, _ = os.ReadFile(d.genPath)
+		d.genNext.Store(int64(m + genIDEvery))
+	}
+}
+
+// cloned reports whether a snapshot resumption was detected since the last check.
+func (d *cloneDetector) cloned() bool {
+	m := d.mono()
+	off := d.wall().UnixNano() - int64(m)
+	jumped := off-d.offset.Swap(off) > int64(d.maxJump)
+	next := d.genNext.Load()
+	if d.genPath != "" && int64(m) >= next && d.genNext.CompareAndSwap(next, int64(m+genIDEvery)) {
+		id, _ := os.ReadFile(d.genPath)
+		d.mu.Lock()
+		if id != nil && !bytes.Equal(id, d.genID) {
+			jumped, d.genID = true, id
+		}
+		d.mu.Unlock()
+	}
+	return jumped
+}
+
+// reseed makes every shard re-key on its next use.
+func (p *pool) reseed() { p.epoch.Add(1) }
+
+// reseed re-keys l from crypto/rand, ending its reproducible stream.
+func (l *lockedChaCha8) reseed() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.c, l.h = newChaCha8(), health{}
+}
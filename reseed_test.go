@@ -0,0 +1,90 @@
+package uid_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReseed(t *testing.T) {
+	// clones resuming with identical state diverge once reseeded
+	a, b := uid.NewGenerator(uid.WithSeed([32]byte{})), uid.NewGenerator(uid.WithSeed([32]byte{}))
+	assert.Exactly(t, a.NewV4(), b.NewV4())
+	b.Reseed()
+	assert.NotEqual(t, a.NewV4(), b.NewV4())
+	// pool shards re-key from crypto/rand on next use
+	g := uid.NewGenerator()
+	_ = g.NewV4()
+	var seeds int
+	defer uid.SetCryptoRead(func(b []byte) (int, error) {
+		seeds++
+		for i := range b {
+			b[i] = byte(seeds)
+		}
+		return len(b), nil
+	})()
+	epoch := uid.EntropyEpoch(g)
+	g.Reseed()
+	assert.Exactly(t, epoch+1, uid.EntropyEpoch(g))
+	_ = g.NewV4()
+	assert.Positive(t, seeds) // the shard from the old epoch re-keyed
+	// sources other than ChaCha8 are left alone
+	before := seeds
+	uid.NewGenerator(uid.WithCryptoEntropy()).Reseed()
+	assert.Exactly(t, before, seeds)
+	uid.Reseed()
+	assert.NotPanics(t, func() { _ = uid.NewV4() })
+}
+
+func TestCloneDetectionClockJump(t *testing.T) {
+	wall, mono := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), time.Duration(0)
+	var detected int
+	g := uid.NewGenerator(uid.WithSeed([32]byte{}), uid.WithClock(func() time.Time { return wall }),
+		uid.WithCloneDetection(time.Second, "", func() { detected++ }))
+	uid.SetCloneMonotonic(g, func() time.Duration { return mono })
+	ref := uid.NewGenerator(uid.WithSeed([32]byte{}))
+	// wall and monotonic time advancing together, and small slews, are not a resume
+	for range 10 {
+		wall, mono = wall.Add(time.Minute), mono+time.Minute
+		assert.Exactly(t, ref.NewV4(), g.NewV4())
+	}
+	wall = wall.Add(500 * time.Millisecond)
+	assert.Exactly(t, ref.NewV4(), g.NewV4())
+	wall = wall.Add(-time.Hour)
+	assert.Exactly(t, ref.NewV4(), g.NewV4())
+	assert.Zero(t, detected)
+	// snapshot resumed: monotonic stood still while the wall clock moved on
+	wall = wall.Add(time.Hour)
+	assert.NotEqual(t, ref.NewV4(), g.NewV4())
+	assert.Exactly(t, 1, detected)
+	_ = g.NewV7()
+	assert.Exactly(t, 1, detected)
+}
+
+func TestCloneDetectionGenID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vmgenid")
+	assert.NoError(t, os.WriteFile(path, []byte("1"), 0o600))
+	wall, mono := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), time.Duration(0)
+	var detected int
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return wall.Add(mono) }),
+		uid.WithCloneDetection(time.Second, path, func() { detected++ }))
+	uid.SetCloneMonotonic(g, func() time.Duration { return mono })
+	assert.NoError(t, os.WriteFile(path, []byte("2"), 0o600))
+	_ = g.NewV4()
+	assert.Zero(t, detected) // not reread yet
+	mono += 100 * time.Millisecond
+	_ = g.NewV4()
+	assert.Exactly(t, 1, detected)
+	mono += 100 * time.Millisecond
+	_ = g.NewV4()
+	assert.Exactly(t, 1, detected)
+	// unreadable is ignored
+	assert.NoError(t, os.Remove(path))
+	mono += 100 * time.Millisecond
+	_ = g.NewV4()
+	assert.Exactly(t, 1, detected)
+}
@@ -0,0 +1,65 @@
+package uid
+
+import (
+	"errors"
+	"os"
+)
+
+/*
+WithSharedState makes the Generator's strict paths (NewV7Strict, NewV7StrictAt, FillV7, SeqV7 and strict holds) draw
+from one monotonic sequence shared by every process on the host that uses the same path. The last issued slot is kept in
+the file at path under an exclusive flock (Linux only). When the file cannot be opened, locked or updated (or on other
+platforms) the error is passed to onError (when non-nil) and the Generator falls back to its in-process sequence, which
+never goes below anything it read from the file, so IDs are no longer unique across processes. Close releases the file.
+*/
+func WithSharedState(path string, onError func(error)) Option {
+	return func(g *Generator) { g.sharedPath, g.sharedErr = path, onError }
+}
+
+/*
+Close releases the file opened by WithSharedState. Afterwards the Generator keeps working on its in-process sequence.
+Close is safe to call more than once and on Generators without shared state.
+*/
+func (g *Generator) Close() error {
+	if g.shared == nil {
+		return nil
+	}
+	return g.shared.close()
+}
+
+// reports a shared state failure.
+func (g *Generator) sharedFail(err error) {
+	if g.sharedErr != nil {
+		g.sharedErr(err)
+	}
+}
+
+// claims up to n slots from the shared sequence, see claimUpTo. ok is false when the shared state is unusable.
+func (g *Generator) claimShared(at stamp, n int, limit stamp) (stamp, int, bool) {
+	if g.shared == nil {
+		return 0, 0, false
+	}
+	first, k, err := g.shared.claim(func(last stamp) (stamp, int, stamp) {
+		last = max(last, stamp(g.last.Load()))
+		first := max(at, last.next())
+		if first > limit {
+			return first, 0, last
+		}
+		k := min(n, int(scale-first.slot()))
+		end := min(first+stamp(k-1), maxStamp)
+		for { // keep the in-process sequence ahead for fallback
+			cur := g.last.Load()
+			if cur >= uint64(end) || g.last.CompareAndSwap(cur, uint64(end)) {
+				break
+			}
+		}
+		return first, k, end
+	})
+	if err != nil {
+		if !errors.Is(err, os.ErrClosed) {
+			g.sharedFail(err)
+		}
+		return 0, 0, false
+	}
+	return first, k, true
+}
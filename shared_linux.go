@@ -0,0 +1,61 @@
+package uid
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// sharedState is a flock protected file holding the last issued stamp of a host wide strict sequence.
+type sharedState struct {
+	mu sync.Mutex // flock does not exclude goroutines sharing the file descriptor
+	f  *os.File
+}
+
+// opens the shared state at path.
+func openShared(path string) (*sharedState, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600) //nolint:mnd // owner only
+	if err != nil {
+		return nil, err
+	}
+	return &sharedState{f: f}, nil
+}
+
+// closes the file, later claims fail with os.ErrClosed.
+func (s *sharedState) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// claim runs next on the last issued stamp under an exclusive lock and stores the stamp it returns as the new last.
+func (s *sharedState) claim(next func(last stamp) (stamp, int, stamp)) (stamp, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return 0, 0, os.ErrClosed
+	}
+	fd := int(s.f.Fd()) //nolint:gosec // fds fit in int
+	if err := syscall.Flock(fd, syscall.LOCK_EX); err != nil {
+		return 0, 0, os.NewSyscallError("flock", err)
+	}
+	defer syscall.Flock(fd, syscall.LOCK_UN) //nolint:errcheck // closing the lock cannot be handled
+	var buf [8]byte
+	if n, err := s.f.ReadAt(buf[:], 0); err != nil && (n != 0 || !errors.Is(err, io.EOF)) {
+		return 0, 0, err
+	} // an empty (new) file starts the sequence at 0
+	first, k, end := next(stamp(binary.BigEndian.Uint64(buf[:])))
+	binary.BigEndian.PutUint64(buf[:], uint64(end))
+	if _, err := s.f.WriteAt(buf[:], 0); err != nil {
+		return 0, 0, err
+	}
+	return first, k, nil
+}
@@ -0,0 +1,127 @@
+package uid_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sharedWorkerEnv = "UID_SHARED_WORKER"
+
+// TestSharedStateWorker is the child process of TestSharedStateProcesses.
+func TestSharedStateWorker(t *testing.T) {
+	path := os.Getenv(sharedWorkerEnv)
+	if path == "" {
+		t.Skip("only runs as a child of TestSharedStateProcesses")
+	}
+	frozen, err := strconv.ParseInt(os.Getenv(sharedWorkerEnv+"_AT"), 10, 64)
+	require.NoError(t, err)
+	g := uid.NewGenerator(uid.WithSharedState(path, nil), uid.WithClock(func() time.Time { return time.Unix(0, frozen) }))
+	for range 2000 {
+		fmt.Println(g.NewV7Strict()) //nolint:forbidigo // reported to parent on stdout
+	}
+}
+
+func TestSharedStateProcesses(t *testing.T) {
+	// every worker has the same frozen clock, without coordination they would all issue the same slots
+	path := filepath.Join(t.TempDir(), "uid.state")
+	at := strconv.FormatInt(time.Now().UnixNano(), 10)
+	const workers = 4
+	cmds, outs := make([]*exec.Cmd, workers), make([]*bytes.Buffer, workers)
+	for i := range workers {
+		cmds[i] = exec.Command(os.Args[0], "-test.run=^TestSharedStateWorker$", "-test.count=1") //nolint:gosec // self
+		cmds[i].Env = append(os.Environ(), sharedWorkerEnv+"="+path, sharedWorkerEnv+"_AT="+at)
+		outs[i] = new(bytes.Buffer)
+		cmds[i].Stdout = outs[i]
+		require.NoError(t, cmds[i].Start())
+	}
+	slots := map[time.Time]bool{}
+	for i, cmd := range cmds {
+		require.NoError(t, cmd.Wait())
+		ids := []uid.UUID{}
+		for sc := bufio.NewScanner(outs[i]); sc.Scan(); {
+			if id, ok := uid.Parse(sc.Text()); ok {
+				ids = append(ids, id)
+				slots[id.Time()] = true
+			}
+		}
+		assert.Len(t, ids, 2000)
+		assert.True(t, slices.IsSortedFunc(ids, uid.Compare))
+	}
+	assert.Len(t, slots, workers*2000)
+	// a later generator continues the sequence
+	frozen := time.Unix(0, must(strconv.ParseInt(at, 10, 64)))
+	g := uid.NewGenerator(uid.WithSharedState(path, nil), uid.WithClock(func() time.Time { return frozen }))
+	next := g.NewV7Strict().Time()
+	for s := range slots {
+		assert.True(t, next.After(s))
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestSharedStateFallback(t *testing.T) {
+	var reported []error
+	g := uid.NewGenerator(uid.WithSharedState(filepath.Join(t.TempDir(), "missing", "uid.state"), func(err error) {
+		reported = append(reported, err)
+	}))
+	require.Len(t, reported, 1)
+	require.ErrorIs(t, reported[0], fs.ErrNotExist)
+	prev := g.NewV7Strict()
+	for range 1000 {
+		id := g.NewV7Strict()
+		assert.Exactly(t, -1, uid.Compare(prev, id))
+		prev = id
+	}
+}
+
+func TestSharedStateClose(t *testing.T) {
+	reported := 0
+	path := filepath.Join(t.TempDir(), "uid.state")
+	g := uid.NewGenerator(uid.WithSharedState(path, func(error) { reported++ }))
+	prev := g.NewV7Strict()
+	require.NoError(t, g.Close())
+	require.NoError(t, g.Close())
+	for range 1000 { // falls back to the in-process sequence, which stays ahead of the shared one
+		id := g.NewV7Strict()
+		assert.Exactly(t, -1, uid.Compare(prev, id))
+		prev = id
+	}
+	assert.Zero(t, reported)
+	assert.NoError(t, uid.NewGenerator().Close())
+}
+
+func TestSharedStateInProcess(t *testing.T) {
+	// generators sharing a file in one process coordinate too
+	freezeNow := time.Now()
+	path := filepath.Join(t.TempDir(), "uid.state")
+	a := uid.NewGenerator(uid.WithSharedState(path, nil), uid.WithClock(func() time.Time { return freezeNow }))
+	b := uid.NewGenerator(uid.WithSharedState(path, nil), uid.WithClock(func() time.Time { return freezeNow }))
+	ids := []uid.UUID{}
+	for range 500 {
+		ids = append(ids, a.NewV7Strict(), b.NewV7Strict())
+	}
+	buf := make([]uid.UUID, 100)
+	b.FillV7(buf)
+	ids = append(ids, buf...)
+	for i := 1; i < len(ids); i++ {
+		assert.Exactly(t, -1, uid.Compare(ids[i-1], ids[i]))
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package uid
+
+import "errors"
+
+// sharedState is unsupported off Linux, Generators always fall back to their in-process sequence.
+type sharedState struct{}
+
+func openShared(string) (*sharedState, error) { return nil, errors.ErrUnsupported }
+
+func (*sharedState) claim(func(stamp) (stamp, int, stamp)) (stamp, int, error) {
+	return 0, 0, errors.ErrUnsupported
+}
+
+func (*sharedState) close() error { return nil }
@@ -0,0 +1,63 @@
+package uid
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// GeneratorStats is a snapshot of a Generator's counters, see WithStats.
+type GeneratorStats struct {
+	V4 uint64 // v4 IDs generated (including those prefetched by a Pool)
+	V7 uint64 // v7 IDs generated, all modes
+
+	// StrictAhead counts strict slots issued past the clock's (or requested) slot because it was already taken. Strict
+	// generation never waits, this is how often it ran ahead of the clock instead.
+	StrictAhead uint64
+	// StrictRetries counts compare-and-swap retries claiming strict slots, i.e. contention.
+	StrictRetries uint64
+	// StrictWaits and StrictWaitTime count the sleeps NewV7StrictContext took waiting for the clock and their total.
+	StrictWaits    uint64
+	StrictWaitTime time.Duration
+
+	ClockRegressions uint64 // clock readings behind the highest one seen
+	Reseeds          uint64 // Reseed calls, including those triggered by WithCloneDetection
+}
+
+/*
+WithStats makes the Generator count what it generates, see GeneratorStats. Counting costs an atomic add per ID (per
+batch for FillV4 and FillV7) and tracks the highest clock reading seen to notice regressions, so it is off by default.
+To count for the package functions install a Generator with stats as the default, see SetDefault.
+*/
+func WithStats() Option { return func(g *Generator) { g.stats = new(stats) } }
+
+// stats holds a Generator's counters, nil when counting is off.
+type stats struct {
+	v4, v7, ahead, retries, waits, waitNs, regressions, reseeds atomic.Uint64
+}
+
+// Stats returns a snapshot of the default Generator's counters. See Generator.Stats.
+func Stats() GeneratorStats { return std.Stats() }
+
+// Stats returns a snapshot of g's counters, all zero unless g was constructed WithStats.
+func (g *Generator) Stats() GeneratorStats {
+	s := g.stats
+	if s == nil {
+		return GeneratorStats{}
+	}
+	return GeneratorStats{
+		V4: s.v4.Load(), V7: s.v7.Load(),
+		StrictAhead: s.ahead.Load(), StrictRetries: s.retries.Load(),
+		StrictWaits: s.waits.Load(), StrictWaitTime: time.Duration(s.waitNs.Load()), //nolint:gosec // ns fit
+		ClockRegressions: s.regressions.Load(), Reseeds: s.reseeds.Load(),
+	}
+}
+
+// PublishExpvar publishes the default Generator's Stats as the expvar name. See Generator.PublishExpvar.
+func PublishExpvar(name string) { std.PublishExpvar(name) }
+
+// PublishExpvar publishes g's Stats, snapshotted on every read, as the expvar name. Like expvar.Publish it panics if
+// name is already in use.
+func (g *Generator) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return g.Stats() }))
+}
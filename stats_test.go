@@ -0,0 +1,72 @@
+package uid_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return now }), uid.WithStats())
+	ids := make([]uid.UUID, 300)
+	g.FillV4(ids)
+	_ = g.NewV4()
+	_ = g.NewV7()
+	_ = g.NewV7At(now)
+	_ = g.NewV7Strict()
+	_ = g.NewV7Strict() // frozen clock, runs ahead
+	g.FillV7(ids)       // all ahead
+	now = now.Add(-time.Second)
+	_ = g.NewV7()
+	g.Reseed()
+	stats := g.Stats()
+	assert.Exactly(t, uint64(301), stats.V4)
+	assert.Exactly(t, uint64(305), stats.V7)
+	assert.Exactly(t, uint64(301), stats.StrictAhead)
+	assert.Exactly(t, uint64(1), stats.ClockRegressions)
+	assert.Exactly(t, uint64(1), stats.Reseeds)
+	assert.Zero(t, stats.StrictWaits)
+	// other modes
+	for _, mode := range []uid.V7Mode{uid.V7Strict, uid.V7Counter, uid.V7MonotonicRandom} {
+		g := uid.NewGenerator(uid.WithV7Mode(mode), uid.WithStats())
+		_ = g.NewV7()
+		assert.Exactly(t, uint64(1), g.Stats().V7)
+	}
+	// off by default
+	g = uid.NewGenerator()
+	_ = g.NewV4()
+	assert.Zero(t, g.Stats())
+}
+
+func TestStatsStrictWaits(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return now }), uid.WithStats())
+	_, _ = g.NewV7StrictContext(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := g.NewV7StrictContext(ctx)
+	assert.Error(t, err)
+	stats := g.Stats()
+	assert.NotZero(t, stats.StrictWaits)
+	assert.Positive(t, stats.StrictWaitTime)
+}
+
+func TestPublishExpvar(t *testing.T) {
+	g := uid.NewGenerator(uid.WithStats())
+	name := fmt.Sprintf("uid_test_stats_%p", g) // unique across -count runs
+	g.PublishExpvar(name)
+	_ = g.NewV4()
+	var got uid.GeneratorStats
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &got))
+	assert.Exactly(t, uint64(1), got.V4)
+	assert.Panics(t, func() { g.PublishExpvar(name) })
+	uid.PublishExpvar(name + "_default")
+	assert.Exactly(t, uid.Stats(), uid.GeneratorStats{})
+}
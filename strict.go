@@ -0,0 +1,88 @@
+package uid
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var errPrecision = errors.New("uid: strict v7 is unavailable WithPrecision") //nolint:gochecknoglobals // sentinel
+
+// maxStrictBackoff caps how long NewV7StrictContext sleeps between reads of a clock that stopped moving.
+const maxStrictBackoff = 10 * time.Millisecond
+
+// WithMaxLead bounds how far ahead of the clock NewV7StrictContext and TryNewV7Strict may issue slots. Defaults to
+// zero, only slots the clock has reached are issued, so the two wait for (or report) the clock when the current slot is
+// exhausted. NewV7Strict is unaffected and never waits.
+func WithMaxLead(d time.Duration) Option { return func(g *Generator) { g.maxLead = d } }
+
+// TryNewV7Strict is NewV7Strict that refuses to run ahead of the clock. See Generator.TryNewV7Strict.
+func TryNewV7Strict() (UUID, bool) { return std.TryNewV7Strict() }
+
+// TryNewV7Strict returns a strict v7 UUID and true, or the Nil UUID and false without waiting when the slots up to the
+// maximum lead (see WithMaxLead) past g's clock are exhausted. Always false on a WithPrecision Generator.
+func (g *Generator) TryNewV7Strict() (UUID, bool) {
+	if g.precision > 0 {
+		return UUID{}, false
+	}
+	now := g.clock()
+	at, _ := g.nowFrom(now)
+	s, k := g.claimUpTo(at, 1, stampOf(now.Add(g.maxLead)))
+	if k == 0 {
+		return UUID{}, false
+	}
+	return g.make7(s), true
+}
+
+// NewV7StrictContext is NewV7Strict that waits instead of running ahead of the clock. See
+// Generator.NewV7StrictContext.
+func NewV7StrictContext(ctx context.Context) (UUID, error) { return std.NewV7StrictContext(ctx) }
+
+/*
+NewV7StrictContext returns a strict v7 UUID, sleeping while the slots up to the maximum lead (see WithMaxLead) past g's
+clock are exhausted. It is the only generation path that can fail: it gives up with ctx's error when ctx is done first,
+which bounds the wait on a stalled clock, and always fails on a WithPrecision Generator.
+*/
+func (g *Generator) NewV7StrictContext(ctx context.Context) (UUID, error) {
+	if g.precision > 0 {
+		return UUID{}, errPrecision
+	}
+	var (
+		wait    *time.Timer
+		prev    time.Time
+		backoff time.Duration
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return UUID{}, err //nolint:wrapcheck // passthru
+		}
+		now := g.clock()
+		at, _ := g.nowFrom(now)
+		s, k := g.claimUpTo(at, 1, stampOf(now.Add(g.maxLead)))
+		if k > 0 {
+			return g.make7(s), nil
+		}
+		if now.Equal(prev) { // stalled clock, back off instead of polling it
+			backoff = min(backoff*2, maxStrictBackoff)
+		} else {
+			backoff = time.Microsecond
+		}
+		prev = now
+		d := max(s.time().Sub(now.Add(g.maxLead)), backoff)
+		if wait == nil {
+			wait = time.NewTimer(d)
+			defer wait.Stop()
+		} else {
+			wait.Reset(d)
+		}
+		start := time.Now()
+		select {
+		case <-ctx.Done():
+		case <-wait.C:
+		}
+		if g.stats != nil {
+			g.stats.waits.Add(1)
+			g.stats.waitNs.Add(uint64(time.Since(start))) //nolint:gosec // positive
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package uid_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryV7Strict(t *testing.T) {
+	frozen := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return frozen }))
+	id, ok := g.TryNewV7Strict()
+	assert.True(t, ok)
+	assert.Exactly(t, frozen, id.Time().UTC())
+	// slot exhausted until the clock moves
+	id2, ok := g.TryNewV7Strict()
+	assert.False(t, ok)
+	assert.Exactly(t, uid.Nil(), id2)
+	// plain strict still runs ahead, try now has to wait for it too
+	ahead := g.NewV7Strict()
+	assert.Exactly(t, -1, uid.Compare(id, ahead))
+	_, ok = g.TryNewV7Strict()
+	assert.False(t, ok)
+	frozen = frozen.Add(time.Microsecond)
+	id3, ok := g.TryNewV7Strict()
+	assert.True(t, ok)
+	assert.Exactly(t, -1, uid.Compare(ahead, id3))
+	// package function
+	_, ok = uid.TryNewV7Strict()
+	assert.True(t, ok)
+}
+
+func TestTryV7StrictMaxLead(t *testing.T) {
+	frozen := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return frozen }), uid.WithMaxLead(time.Millisecond))
+	prev := uid.Nil()
+	for range 4097 { // the clock's slot plus a millisecond's worth ahead of it
+		id, ok := g.TryNewV7Strict()
+		assert.True(t, ok)
+		assert.Exactly(t, -1, uid.Compare(prev, id))
+		prev = id
+	}
+	_, ok := g.TryNewV7Strict()
+	assert.False(t, ok)
+}
+
+func TestV7StrictContext(t *testing.T) {
+	var now atomic.Int64
+	now.Store(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).UnixNano())
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return time.Unix(0, now.Load()) }))
+	first, err := g.NewV7StrictContext(context.Background())
+	assert.NoError(t, err)
+	// stalled clock, gives up at the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	id, err := g.NewV7StrictContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Exactly(t, uid.Nil(), id)
+	// already cancelled
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = g.NewV7StrictContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	// clock resumes while waiting
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		now.Add(int64(time.Millisecond))
+	}()
+	id, err = g.NewV7StrictContext(context.Background())
+	assert.NoError(t, err)
+	assert.Exactly(t, -1, uid.Compare(first, id))
+	assert.Exactly(t, time.Unix(0, now.Load()).UTC(), id.Time().UTC())
+	// package function
+	_, err = uid.NewV7StrictContext(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestV7StrictClockPolicy(t *testing.T) {
+	var now atomic.Int64
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	now.Store(at.UnixNano())
+	var events atomic.Int64
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return time.Unix(0, now.Load()) }), uid.WithStats(),
+		uid.WithClockPolicy(uid.ClockFollow, func(e uid.ClockEvent) {
+			if e.Kind == uid.ClockRegressed {
+				events.Add(1)
+			}
+		}))
+	_, ok := g.TryNewV7Strict()
+	assert.True(t, ok)
+	now.Store(at.Add(-time.Second).UnixNano())
+	_, ok = g.TryNewV7Strict()
+	assert.False(t, ok)
+	assert.EqualValues(t, 1, events.Load())
+	assert.EqualValues(t, 1, g.Stats().ClockRegressions)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := g.NewV7StrictContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Greater(t, events.Load(), int64(1))
+	assert.EqualValues(t, events.Load(), g.Stats().ClockRegressions)
+}
+
+func TestV7StrictContextBackoff(t *testing.T) {
+	// a stalled clock is polled with growing sleeps, not once per slot (about 250ns)
+	frozen := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return frozen }), uid.WithStats())
+	_, _ = g.NewV7StrictContext(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := g.NewV7StrictContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, g.Stats().StrictWaits, uint64(50))
+}
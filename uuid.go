@@ -125,5 +125,6 @@ func (u UUID) shifted() (out [16]byte) {
 	return
 }
 
-// Compare is a helper for sorting/deduping by monotonic time. Note: Sorting non-v7 IDs is a design flaw.
+// Compare is a helper for sorting/deduping by monotonic time. Note: Sorting non-v7 IDs is a design flaw. See also
+// CompareAll.
 func Compare(a, b UUID) int { return bytes.Compare(a.b[:8], b.b[:8]) } // unix_ms_ts and rand_a (monotonic times)
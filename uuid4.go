@@ -1,11 +1,17 @@
 package uid
 
 // NewV4 returns a new v4 UUID.
-func NewV4() UUID {
+func NewV4() UUID { return std.NewV4() }
+
+// NewV4 returns a new v4 UUID.
+func (g *Generator) NewV4() UUID {
 	var b [16]byte
-	_, _ = rng.Read(b[:]) //nolint:errcheck // does not return errors
+	g.read(b[:])
 	// version, variant
 	b[6], b[8] = (b[6]&0x0f)|0x40, (b[8]&0x3f)|0x80 //nolint:mnd // lob
+	if g.stats != nil {
+		g.stats.v4.Add(1)
+	}
 
 	return UUID{b}
 }
@@ -0,0 +1,66 @@
+package uid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/byron-janrain/uid"
+	googleuuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaces(t *testing.T) {
+	assert.Exactly(t, googleuuid.NameSpaceDNS.String(), uid.NamespaceDNS.String())
+	assert.Exactly(t, googleuuid.NameSpaceURL.String(), uid.NamespaceURL.String())
+	assert.Exactly(t, googleuuid.NameSpaceOID.String(), uid.NamespaceOID.String())
+	assert.Exactly(t, googleuuid.NameSpaceX500.String(), uid.NamespaceX500.String())
+}
+
+func TestV5(t *testing.T) {
+	// RFC9562 appendix A.4
+	id := uid.NewV5(uid.NamespaceDNS, []byte("www.example.com"))
+	assert.Exactly(t, "2ed6657d-e927-568b-95e1-2665a8aea6a2", id.String())
+	assert.Exactly(t, uid.Version5, id.Version())
+	assert.Exactly(t, uid.Variant9562, id.Variant())
+	assert.Exactly(t, id, uid.NewV5(uid.NamespaceDNS, []byte("www.example.com")))
+	assert.True(t, id.Time().IsZero())
+}
+
+func TestV3(t *testing.T) {
+	// RFC9562 appendix A.2
+	id := uid.NewV3(uid.NamespaceDNS, []byte("www.example.com"))
+	assert.Exactly(t, "5df41881-3aed-3515-88a7-2f4a814cf09e", id.String())
+	assert.Exactly(t, uid.Version3, id.Version())
+	assert.Exactly(t, uid.Variant9562, id.Variant())
+}
+
+func TestNameBasedMatchesGoogle(t *testing.T) {
+	custom := uid.NewV4()
+	for _, ns := range []uid.UUID{uid.NamespaceDNS, uid.NamespaceURL, uid.NamespaceOID, uid.NamespaceX500, custom} {
+		gns := googleuuid.UUID(ns.Bytes())
+		for _, name := range []string{"", "a", "user@example.com", "https://example.com/x?y=z", strings.Repeat("x", 1000)} {
+			assert.Exactly(t, googleuuid.NewSHA1(gns, []byte(name)).String(), uid.NewV5(ns, []byte(name)).String())
+			assert.Exactly(t, googleuuid.NewMD5(gns, []byte(name)).String(), uid.NewV3(ns, []byte(name)).String())
+		}
+	}
+}
+
+func TestNameBasedRoundTrip(t *testing.T) {
+	for range 1000 {
+		name := uid.NewV4().Bytes()
+		for _, id := range []uid.UUID{uid.NewV5(uid.NamespaceURL, name), uid.NewV3(uid.NamespaceURL, name)} {
+			for _, s := range []string{
+				id.String(), strings.ToUpper(id.String()), `"` + id.String() + `"`, string(id.Bytes()),
+				id.Compact32(), strings.ToLower(id.Compact32()), id.Compact64(),
+			} {
+				parsed, ok := uid.Parse(s)
+				assert.True(t, ok, s)
+				assert.Exactly(t, id, parsed)
+			}
+			assert.Exactly(t, byte('A')+byte(id.Version()), id.Compact32()[0])
+			back, ok := uid.FromPythonShort(uid.ToPythonShort(id))
+			assert.True(t, ok)
+			assert.Exactly(t, id, back)
+		}
+	}
+}
@@ -1,83 +1,208 @@
 package uid
 
 import (
-	"sync"
 	"time"
 )
 
 // NewV7 constructs a new v7 UUID. Enforces method 3 of monotonicity.
-func NewV7() UUID { return make7(tick) }
+func NewV7() UUID { return std.NewV7() }
+
+// NewV7 constructs a new v7 UUID using g's V7Mode.
+func (g *Generator) NewV7() UUID {
+	switch g.mode {
+	case V7Strict:
+		return g.NewV7Strict()
+	case V7Counter:
+		return g.makeCounter()
+	case V7MonotonicRandom:
+		return g.makeMonotonicRandom()
+	}
+	s, held := g.now()
+	if g.precision > 0 { // held or not, the granule never goes backwards
+		return g.makeCoarse(s.ms())
+	}
+	if held { // keep counting forward from the held timestamp
+		s = s.next()
+	}
+	if g.policy == ClockHold { // held or recovered, never behind the slots counted while held
+		s, _ = g.claimFrom(s, 1)
+	}
+	return g.make7(s)
+}
+
+/*
+NewV7At constructs a v7 UUID for t (e.g. backfilling historical rows or scheduling future jobs) with the same method 3
+sub-millisecond precision as NewV7. v7 can only represent times from the Unix epoch to 2^48-1 ms after it (year
+10889), t outside that range is clamped to the nearest end.
+*/
+func NewV7At(t time.Time) UUID { return std.NewV7At(t) }
+
+// NewV7At constructs a v7 UUID for t. See NewV7At.
+func (g *Generator) NewV7At(t time.Time) UUID {
+	if g.precision > 0 {
+		return g.makeCoarse(stampOf(t).ms())
+	}
+	return g.make7(stampOf(t))
+}
 
 const scale, m, mf64, slot2ns, ns2slot = 4096, 1_000_000, float64(m), mf64 / float64(scale), float64(scale) / mf64
 
 // Time returns the embedded timestamp of UUID. For non-V7 zero(time.Time) is returned. If you don't pre-check version
 // use `.IsZero()` to ensure time is "real".
-//
-//nolint:mnd // locality of behavior
 func (u UUID) Time() time.Time {
 	if u.Version() != Version7 {
 		return time.Time{}
 	}
+	return u.stamp().time()
+}
+
+// returns u's unix_ts_ms and rand_a as a stamp.
+//
+//nolint:mnd // locality of behavior
+func (u UUID) stamp() stamp {
 	// rebuild unix_ts_ms
 	ms := int64(u.b[0])<<40 | int64(u.b[1])<<32 | int64(u.b[2])<<24 | int64(u.b[3])<<16 | int64(u.b[4])<<8 | int64(u.b[5])
 	ra := uint16(u.b[6]&0x0f)<<8 | // top 4 of rand_a
 		uint16(u.b[7]) // bottom 8 of rand_a
-	return time.Unix(0, ms*m+unslot(ra))
+	return stamp(ms)<<12 | stamp(ra)
 }
 
 //nolint:mnd // locality of behavior
-func make7(tickFn func() int64) UUID {
+func (g *Generator) make7(s stamp) UUID {
 	var b [16]byte
-	ns := tickFn()
-	if ns < 0 {
-		panic("v7 UUID does not support time before epoch")
+	put7(&b, s)
+	if g.stats != nil {
+		g.stats.v7.Add(1)
 	}
+	// fill rand_b
+	g.read(b[8:])
+	// variant
+	b[8] = (b[8] & 0x3f) | 0x80
+	g.putNode(&b)
+	return UUID{b}
+}
+
+// sets unix_ts_ms, version and rand_a of b from s.
+//
+//nolint:mnd // locality of behavior
+func put7(b *[16]byte, s stamp) {
 	// set unix_ts_ms
-	ms := ns / m
+	ms := s.ms()
 	b[0], b[1], b[2], b[3], b[4], b[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
 	// set rand_a
-	ra := slot(ns)
+	ra := s.slot()
 	b[6] = byte((ra >> 8)) & 0x0f // set top 4 bytes of rand_a
 	b[7] = byte(ra)
-	// fill rand_b
-	_, _ = rng.Read(b[8:]) //nolint:errcheck // never returns errors
-	// version, variant
-	b[6], b[8] = (b[6]&0x0f)|0x70, (b[8]&0x3f)|0x80
-	return UUID{b}
+	// version
+	b[6] |= 0x70
 }
 
-func tick() int64 { return now().UnixNano() }
+func (g *Generator) tick() stamp {
+	s, _ := g.now()
+	return s
+}
 
 /*
 NewV7Strict returns a v7 UUID with guaranteed (beyond RFC method 3) local monotonicity.
 You don't need this, if you think you need finer than sub-millisecond precision in IDs, what you really need is a
 sequence generator and not more accurate timekeeping.
 */
-func NewV7Strict() UUID { return make7(tickBatch) }
+func NewV7Strict() UUID { return std.NewV7Strict() }
 
-//nolint:gochecknoglobals // unexported
-var (
-	mux      = new(sync.Mutex)
-	lastTime = slottedNow()
-)
+// NewV7Strict returns a v7 UUID with guaranteed (beyond RFC method 3) Generator-local monotonicity.
+func (g *Generator) NewV7Strict() UUID {
+	if g.precision > 0 {
+		return g.makeCoarse(g.tick().ms())
+	}
+	s, _ := g.claim(1)
+	return g.make7(s)
+}
+
+/*
+NewV7StrictAt constructs a v7 UUID for t that sorts strictly after every ID issued by NewV7Strict or NewV7StrictAt.
+When t is not after the last issued slot the next free slot is used instead of t, so backfills should use a dedicated
+Generator and feed it times in ascending order.
+*/
+func NewV7StrictAt(t time.Time) UUID { return std.NewV7StrictAt(t) }
+
+// NewV7StrictAt constructs a v7 UUID for t that sorts strictly after every strict ID g issued. See NewV7StrictAt.
+func (g *Generator) NewV7StrictAt(t time.Time) UUID {
+	if g.precision > 0 {
+		return g.makeCoarse(stampOf(t).ms())
+	}
+	s, _ := g.claimFrom(stampOf(t), 1)
+	return g.make7(s)
+}
+
+/*
+claim atomically claims up to n consecutive strict slots within a single ms and returns the first and how many were
+claimed. Claims start at the clock's slot when the clock has moved past the last issued slot, otherwise right after the
+last issued slot, running logically ahead of the clock until real time catches up. Lock-free and never waits on the
+clock, contention only costs compare-and-swap retries.
+*/
+func (g *Generator) claim(n int) (stamp, int) { return g.claimFrom(g.tick(), n) }
+
+func (g *Generator) claimFrom(at stamp, n int) (stamp, int) { return g.claimUpTo(at, n, maxStamp) }
 
-func tickBatch() int64 {
-	defer mux.Unlock()
-	mux.Lock()
-	n := slottedNow()
-	for !n.After(lastTime) {
-		n = slottedNow()
+// claimUpTo is claimFrom refusing (claiming 0 slots) when the first slot would be after limit.
+func (g *Generator) claimUpTo(at stamp, n int, limit stamp) (stamp, int) {
+	first, k := g.claimSeq(at, n, limit)
+	if k > 0 {
+		g.persist(first + stamp(k-1))
 	}
-	lastTime = n
-	return lastTime.UnixNano()
+	if g.stats != nil && k > 0 && first > at {
+		g.stats.ahead.Add(uint64(k))
+	}
+	return first, k
 }
 
-// returns the Time of t's slot (1/4096 of ms).
-func slottedNow() time.Time {
-	n := now()
-	return time.Unix(0, n.UnixMilli()*m+unslot(slot(n.UnixNano())))
+func (g *Generator) claimSeq(at stamp, n int, limit stamp) (stamp, int) {
+	if first, k, ok := g.claimShared(at, n, limit); ok {
+		return first, k
+	}
+	for {
+		last := stamp(g.last.Load())
+		first := max(at, last.next())
+		if first > limit {
+			return first, 0
+		}
+		k := min(n, int(scale-first.slot()))
+		if g.last.CompareAndSwap(uint64(last), uint64(min(first+stamp(k-1), maxStamp))) {
+			return first, k
+		}
+		if g.stats != nil {
+			g.stats.retries.Add(1)
+		}
+	}
 }
 
+// stamp packs unix_ts_ms and the rand_a slot as ms<<12 | slot, so consecutive stamps are consecutive slots.
+type stamp uint64
+
+const maxMs, maxStamp = 1<<48 - 1, stamp(maxMs<<12 | (scale - 1))
+
+// returns t's stamp, clamped to the range v7 can represent (Unix epoch to year 10889).
+func stampOf(t time.Time) stamp {
+	switch sec := t.Unix(); {
+	case sec < 0:
+		return 0
+	case sec > maxMs/1000: // avoid UnixMilli overflow
+		return maxStamp
+	}
+	ms := t.UnixMilli()
+	if ms > maxMs {
+		return maxStamp
+	}
+	return stamp(ms)<<12 | stamp(slot(int64(t.Nanosecond())))
+}
+
+func (s stamp) ms() int64    { return int64(s >> 12) }          //nolint:mnd // lob
+func (s stamp) slot() uint16 { return uint16(s & (scale - 1)) } //nolint:gosec // masked
+func (s stamp) next() stamp  { return min(s+1, maxStamp) }      // saturates at year 10889
+
+// UnixNano overflows in 2262, long before unix_ts_ms does.
+func (s stamp) time() time.Time { return time.UnixMilli(s.ms()).Add(time.Duration(unslot(s.slot()))) }
+
 // returns ns from a given slot (rand_a).
 func unslot(randA uint16) int64 {
 	ns := float64(randA) * slot2ns
@@ -3,6 +3,7 @@ package uid_test
 import (
 	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,12 +34,10 @@ func TestV7(t *testing.T) {
 	assert.Exactly(t, id, id2)
 }
 
-func TestPreEpochPanic(t *testing.T) {
-	// check that negative times panic (impossible outside of this unit test)
+func TestPreEpochClamps(t *testing.T) {
+	// check that negative times clamp to epoch (impossible outside of this unit test)
 	defer uid.SetNowFunc(func() time.Time { return time.Unix(-10, 0) })()
-	assert.Panics(t, func() {
-		_ = uid.NewV7()
-	})
+	assert.Exactly(t, int64(0), uid.NewV7().Time().UnixNano())
 }
 
 func TestEpochEdgeCase(t *testing.T) {
@@ -88,25 +87,26 @@ func TestV7StrictIsV7(t *testing.T) {
 }
 
 func TestSanityBatching(t *testing.T) {
-	// create matching lists as fast as we can across 2ms to ensure capture 1 full ms
+	// stepped clock crossing ms boundaries, with a frozen stretch forcing the strict sequence ahead of the clock
+	clock, calls := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), 0
+	g := uid.NewGenerator(uid.WithClock(func() time.Time {
+		if calls++; calls < 5_000 || calls > 15_000 {
+			clock = clock.Add(100 * time.Nanosecond)
+		}
+		return clock
+	}))
 	ts1, ts2 := []uid.UUID{}, []uid.UUID{}
-	for start := time.Now(); time.Since(start) < time.Millisecond; {
-		id := uid.NewV7Strict()
+	for range 30_000 {
+		id := g.NewV7Strict()
 		ts1, ts2 = append(ts1, id), append(ts2, id) // fill both arrays instead of cloning later
 	}
-	mss := map[int64]bool{}
-	for _, i := range ts1 {
-		mss[i.Time().UnixMilli()] = true
-	}
 	ts := map[time.Time]bool{}
 	for _, i := range ts1 {
 		ts[i.Time()] = true
 	}
 	// verify setup
-	assert.NotEmpty(t, ts1)
-	assert.NotEmpty(t, ts2)
 	assert.Exactly(t, ts1, ts2)
-	assert.Len(t, mss, 2) // breaking across ms should only have 2 different ms values
+	assert.NotEqual(t, ts1[0].Time().UnixMilli(), ts1[len(ts1)-1].Time().UnixMilli())
 	// test that times were generated in order
 	assert.True(t, slices.IsSortedFunc(ts1, uid.Compare))
 	// test uuids are unique (includes randomness)
@@ -114,3 +114,100 @@ func TestSanityBatching(t *testing.T) {
 	// assert times are strictly monotonic
 	assert.Exactly(t, len(ts1), len(ts))
 }
+
+func TestV7At(t *testing.T) {
+	check := func(at time.Time) {
+		id := uid.NewV7At(at)
+		assert.Exactly(t, uid.Version7, id.Version())
+		assert.Exactly(t, uid.Variant9562, id.Variant())
+		assert.Exactly(t, at.UnixMilli(), id.Time().UnixMilli())
+		assert.InDelta(t, at.UnixNano(), id.Time().UnixNano(), 300) // 1/4096th of a ms + truncation
+		id2, ok := uid.Parse(id.String())
+		assert.True(t, ok)
+		assert.Exactly(t, id, id2)
+	}
+	check(time.Unix(0, 0))
+	check(time.Date(1999, 12, 31, 23, 59, 59, 999_999_999, time.UTC))
+	check(time.Date(2024, 2, 29, 12, 0, 0, 123_456_789, time.UTC))
+	check(time.Now().Add(24 * time.Hour))
+	// sub-ms precision is preserved beyond where UnixNano overflows (2262)
+	far := time.Date(9999, 1, 1, 0, 0, 0, 500_000, time.UTC)
+	assert.InDelta(t, 500*time.Microsecond, uid.NewV7At(far).Time().Sub(far.Truncate(time.Millisecond)), 300)
+}
+
+func TestV7AtOutOfRange(t *testing.T) {
+	// pre-epoch clamps to epoch
+	assert.Exactly(t, time.Unix(0, 0).UTC(), uid.NewV7At(time.Unix(-1, 999_999_999)).Time().UTC())
+	assert.Exactly(t, time.Unix(0, 0).UTC(), uid.NewV7At(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)).Time().UTC())
+	// beyond 48 bits clamps to the last slot of the last ms
+	last := time.UnixMilli(1<<48 - 1).Add(time.Millisecond - 245).UTC()
+	assert.Exactly(t, last.Truncate(time.Millisecond), uid.NewV7At(last.Add(time.Hour)).Time().UTC().Truncate(time.Millisecond))
+	assert.Exactly(t, "ffffffff-ffff-7fff", uid.NewV7At(time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC)).String()[:18])
+	assert.Exactly(t, "ffffffff-ffff-7fff", uid.NewV7At(time.Unix(1<<62, 0)).String()[:18])
+	assert.Exactly(t, "00000000-0000-7000", uid.NewV7At(time.Unix(-1<<62, 0)).String()[:18])
+}
+
+func TestV7StrictAt(t *testing.T) {
+	g := uid.NewGenerator()
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := g.NewV7StrictAt(base)
+	assert.Exactly(t, base.UnixNano(), first.Time().UnixNano())
+	// same or earlier time takes the next free slot
+	second := g.NewV7StrictAt(base)
+	third := g.NewV7StrictAt(base.Add(-time.Hour))
+	assert.Exactly(t, -1, uid.Compare(first, second))
+	assert.Exactly(t, -1, uid.Compare(second, third))
+	assert.Exactly(t, base.UnixMilli(), third.Time().UnixMilli())
+	// later times are used as is
+	later := base.Add(time.Minute)
+	assert.Exactly(t, later.UnixNano(), g.NewV7StrictAt(later).Time().UnixNano())
+	// saturates at the end of the representable range
+	end := time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Exactly(t, g.NewV7StrictAt(end).Time(), g.NewV7StrictAt(end).Time())
+}
+
+func TestV7StrictFrozenClock(t *testing.T) {
+	// a stalled clock must not block, slots advance logically ahead of it
+	freezeNow := time.Now().Truncate(time.Millisecond)
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return freezeNow }))
+	ids := make([]uid.UUID, 10_000)
+	for i := range ids {
+		ids[i] = g.NewV7Strict()
+	}
+	for i := 1; i < len(ids); i++ {
+		assert.Exactly(t, -1, uid.Compare(ids[i-1], ids[i]))
+	}
+	assert.Exactly(t, freezeNow.UnixMilli(), ids[0].Time().UnixMilli())
+	assert.Exactly(t, freezeNow.UnixMilli()+2, ids[len(ids)-1].Time().UnixMilli()) // 10,000 slots > 2 ms
+	// catches up once the clock passes the logical time
+	freezeNow = freezeNow.Add(time.Second)
+	assert.Exactly(t, freezeNow.UnixMilli(), g.NewV7Strict().Time().UnixMilli())
+}
+
+func TestV7StrictConcurrent(t *testing.T) {
+	freezeNow := time.Now()
+	g := uid.NewGenerator(uid.WithClock(func() time.Time { return freezeNow }))
+	const workers, perWorker = 64, 2000
+	results := make([][]uid.UUID, workers)
+	wg := sync.WaitGroup{}
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]uid.UUID, perWorker)
+			for i := range ids {
+				ids[i] = g.NewV7Strict()
+			}
+			results[w] = ids
+		}()
+	}
+	wg.Wait()
+	slots := map[time.Time]bool{}
+	for _, ids := range results {
+		assert.True(t, slices.IsSortedFunc(ids, uid.Compare))
+		for _, id := range ids {
+			slots[id.Time()] = true
+		}
+	}
+	assert.Len(t, slots, workers*perWorker) // every ID got its own slot
+}
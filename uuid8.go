@@ -0,0 +1,84 @@
+package uid
+
+import (
+	"encoding/binary"
+	"hash"
+	"io"
+)
+
+const customCBits = 62
+
+// NewV8 returns a v8 UUID carrying custom with its version and variant bits overwritten (4 bits of custom[6], 2 bits
+// of custom[8]), leaving 122 bits of vendor-specific payload. See NewV8Fields to build one from the RFC9562 fields.
+func NewV8(custom [16]byte) UUID {
+	// version, variant
+	custom[6], custom[8] = (custom[6]&0x0f)|0x80, (custom[8]&0x3f)|0x80 //nolint:mnd // lob
+	return UUID{custom}
+}
+
+// NewV8Fields returns a v8 UUID built from the RFC9562 custom_a (low 48 bits of a), custom_b (low 12 bits of b) and
+// custom_c (low 62 bits of c) fields. Higher bits are ignored.
+//
+//nolint:mnd // locality of behavior
+func NewV8Fields(a uint64, b uint16, c uint64) UUID {
+	var out [16]byte
+	binary.BigEndian.PutUint64(out[:8], a<<16|uint64(b&0x0fff))
+	binary.BigEndian.PutUint64(out[8:], c&(1<<customCBits-1))
+	return NewV8(out)
+}
+
+// CustomA returns the 48 bit custom_a field of a v8 UUID. For non-V8 0 is returned.
+//
+//nolint:mnd // locality of behavior
+func (u UUID) CustomA() uint64 {
+	if u.Version() != Version8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(u.b[:8]) >> 16
+}
+
+// CustomB returns the 12 bit custom_b field of a v8 UUID. For non-V8 0 is returned.
+//
+//nolint:mnd // locality of behavior
+func (u UUID) CustomB() uint16 {
+	if u.Version() != Version8 {
+		return 0
+	}
+	return binary.BigEndian.Uint16(u.b[6:8]) & 0x0fff
+}
+
+// CustomC returns the 62 bit custom_c field of a v8 UUID. For non-V8 0 is returned.
+func (u UUID) CustomC() uint64 {
+	if u.Version() != Version8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(u.b[8:]) & (1<<customCBits - 1)
+}
+
+/*
+NewV8Hash returns the name-based v8 UUID of name in namespace hashed with the hash newHash constructs (e.g.
+sha256.New, sha512.New), per RFC9562 appendix B.2, for policies that ban v5's SHA-1. The first 16 bytes of the hash
+make up the UUID. Panics if the hash is shorter than 16 bytes.
+*/
+func NewV8Hash(newHash func() hash.Hash, namespace UUID, name []byte) UUID {
+	return nameBased(newHash16(newHash), Version8, namespace, name)
+}
+
+// NewV8HashReader is NewV8Hash hashing everything read from r as the name, e.g. for content-addressed IDs of large
+// files. It returns the Nil UUID and r's error if reading fails.
+func NewV8HashReader(newHash func() hash.Hash, namespace UUID, r io.Reader) (UUID, error) {
+	h := newHash16(newHash)
+	h.Write(namespace.b[:])
+	if _, err := io.Copy(h, r); err != nil {
+		return UUID{}, err //nolint:wrapcheck // passthru
+	}
+	return hashed(h, Version8), nil
+}
+
+func newHash16(newHash func() hash.Hash) hash.Hash {
+	h := newHash()
+	if h.Size() < 16 { //nolint:mnd // UUID size
+		panic("uid: hash must be at least 16 bytes")
+	}
+	return h
+}
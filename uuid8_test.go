@@ -0,0 +1,105 @@
+package uid_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"hash"
+	"hash/fnv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/byron-janrain/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV8(t *testing.T) {
+	// RFC9562 appendix B.1
+	id := uid.NewV8Fields(0x2489e9ad2ee2, 0x0e00, 0x0ec932d5f69181c0)
+	assert.Exactly(t, "2489e9ad-2ee2-8e00-8ec9-32d5f69181c0", id.String())
+	assert.Exactly(t, uid.Version8, id.Version())
+	assert.Exactly(t, uid.Variant9562, id.Variant())
+	assert.Exactly(t, uint64(0x2489e9ad2ee2), id.CustomA())
+	assert.Exactly(t, uint16(0x0e00), id.CustomB())
+	assert.Exactly(t, uint64(0x0ec932d5f69181c0), id.CustomC())
+	assert.True(t, id.Time().IsZero())
+	// raw payload, version and variant bits overwritten
+	var raw [16]byte
+	for i := range raw {
+		raw[i] = 0xff
+	}
+	id = uid.NewV8(raw)
+	assert.Exactly(t, "ffffffff-ffff-8fff-bfff-ffffffffffff", id.String())
+	assert.Exactly(t, uint64(1<<48-1), id.CustomA())
+	assert.Exactly(t, uint16(1<<12-1), id.CustomB())
+	assert.Exactly(t, uint64(1<<62-1), id.CustomC())
+	// out of range fields are truncated
+	assert.Exactly(t, uid.NewV8(raw), uid.NewV8Fields(1<<64-1, 1<<16-1, 1<<64-1))
+	// non-v8
+	v4 := uid.NewV4()
+	assert.Zero(t, v4.CustomA())
+	assert.Zero(t, v4.CustomB())
+	assert.Zero(t, v4.CustomC())
+}
+
+func TestV8RoundTrip(t *testing.T) {
+	for range 1000 {
+		id := uid.NewV8([16]byte(uid.NewV4().Bytes()))
+		for _, s := range []string{
+			id.String(), strings.ToUpper(id.String()), string(id.Bytes()),
+			id.Compact32(), strings.ToLower(id.Compact32()), id.Compact64(),
+		} {
+			parsed, ok := uid.Parse(s)
+			assert.True(t, ok, s)
+			assert.Exactly(t, id, parsed)
+		}
+		assert.Exactly(t, byte('I'), id.Compact32()[0])
+		assert.Exactly(t, byte('I'), id.Compact64()[0])
+		// codecs
+		j, err := json.Marshal(id)
+		require.NoError(t, err)
+		var fromJSON uid.UUID
+		require.NoError(t, json.Unmarshal(j, &fromJSON))
+		assert.Exactly(t, id, fromJSON)
+		txt, _ := id.MarshalText()
+		var fromText uid.UUID
+		require.NoError(t, fromText.UnmarshalText(txt))
+		assert.Exactly(t, id, fromText)
+		bin, _ := id.MarshalBinary()
+		var fromBinary uid.UUID
+		require.NoError(t, fromBinary.UnmarshalBinary(bin))
+		assert.Exactly(t, id, fromBinary)
+	}
+}
+
+func TestV8Hash(t *testing.T) {
+	// RFC9562 appendix B.2
+	id := uid.NewV8Hash(sha256.New, uid.NamespaceDNS, []byte("www.example.com"))
+	assert.Exactly(t, "5c146b14-3c52-8afd-938a-375d0df1fbf6", id.String())
+	assert.Exactly(t, uid.Version8, id.Version())
+	assert.Exactly(t, uid.Variant9562, id.Variant())
+	assert.Exactly(t, id, uid.NewV8Hash(sha256.New, uid.NamespaceDNS, []byte("www.example.com")))
+	assert.NotEqual(t, id, uid.NewV8Hash(sha512.New, uid.NamespaceDNS, []byte("www.example.com")))
+	assert.NotEqual(t, id, uid.NewV8Hash(sha256.New, uid.NamespaceURL, []byte("www.example.com")))
+	for _, s := range []string{id.String(), id.Compact32(), id.Compact64(), string(id.Bytes())} {
+		parsed, ok := uid.Parse(s)
+		assert.True(t, ok)
+		assert.Exactly(t, id, parsed)
+	}
+	assert.PanicsWithValue(t, "uid: hash must be at least 16 bytes", func() {
+		uid.NewV8Hash(func() hash.Hash { return fnv.New64() }, uid.NamespaceDNS, nil)
+	})
+}
+
+func TestV8HashReader(t *testing.T) {
+	content := bytes.Repeat([]byte("large file "), 100_000)
+	id, err := uid.NewV8HashReader(sha256.New, uid.NamespaceURL, bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Exactly(t, uid.NewV8Hash(sha256.New, uid.NamespaceURL, content), id)
+	id, err = uid.NewV8HashReader(sha256.New, uid.NamespaceURL, iotest.ErrReader(assert.AnError))
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Exactly(t, uid.Nil(), id)
+}